@@ -0,0 +1,93 @@
+package TableWriter
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Alignment defines the horizontal position of an element within the available space
+type Alignment uint
+
+const (
+	// AlignDefault leaves the alignment unspecified, so that the default behavior applies
+	AlignDefault Alignment = iota
+	// Left places the element at the beginning of the available space
+	Left
+	// Center places the element in the middle of the available space
+	Center
+	// Right places the element at the end of the available space
+	Right
+	// Decimal lines up the numbers of a column on their decimal point, placing them at the end of the available
+	// space. Only applies to columns, see [Writer.SetColumnAlignment]
+	Decimal
+)
+
+// SetTableAlignment positions the whole table within the terminal's width, which is useful to center or right-align
+// compact tables such as banner-style summaries. Tables are left-aligned by default, and alignment is ignored when
+// the terminal's width is unknown
+func (w *Writer) SetTableAlignment(align Alignment) {
+	w.tableAlign = align
+}
+
+// WithTableAlignment positions the whole table within the terminal's width. See [Writer.SetTableAlignment]
+func WithTableAlignment(align Alignment) Option {
+	return func(w *Writer) {
+		w.SetTableAlignment(align)
+	}
+}
+
+// SetColumnAlignment aligns the fields of the column at the given index, regardless of the [AlignMiddle] and
+// [AlignRight] flags, so that names, numbers and statuses can be aligned differently within the same table.
+// [AlignDefault] restores the alignment defined by the flags
+func (w *Writer) SetColumnAlignment(col int, align Alignment) {
+	spec := w.columnSpec(col)
+	spec.Align = align
+	w.SetColumnSpec(col, spec)
+}
+
+// WithColumnAlignment aligns the fields of the column at the given index. See [Writer.SetColumnAlignment]
+func WithColumnAlignment(col int, align Alignment) Option {
+	return func(w *Writer) {
+		w.SetColumnAlignment(col, align)
+	}
+}
+
+// isNumericColumn reports whether all the non-empty data fields of the column at the given index are numeric
+func (w *Writer) isNumericColumn(c int) bool {
+	values := w.columnValues(c)
+	for _, value := range values {
+		if _, ok := parseNumber(value); !ok {
+			return false
+		}
+	}
+	return len(values) > 0
+}
+
+// alignTable shifts all the lines of the rendered table by the same amount of spaces, according to the table's
+// alignment, so that the frame is moved as a whole
+func (w *Writer) alignTable(table []byte) []byte {
+	if w.termCols <= 0 || (w.tableAlign != Center && w.tableAlign != Right) {
+		return table
+	}
+	lines := strings.SplitAfter(string(table), "\n")
+	width := 0
+	for _, line := range lines {
+		width = max(width, w.stringWidth(stripEscapeCodes(strings.TrimSuffix(line, "\n"))))
+	}
+	offset := w.termCols - width
+	if w.tableAlign == Center {
+		offset /= 2
+	}
+	if offset <= 0 {
+		return table
+	}
+
+	padding := bytes.Repeat([]byte{' '}, offset)
+	aligned := make([]byte, 0, len(table)+len(lines)*offset)
+	for _, line := range lines {
+		if line != "" {
+			aligned = append(append(aligned, padding...), line...)
+		}
+	}
+	return aligned
+}
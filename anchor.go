@@ -0,0 +1,60 @@
+package TableWriter
+
+import "strings"
+
+// AlignOn aligns the data fields of the column at the given index on the first occurrence of the anchor character,
+// e.g. the colon of durations, the '@' of emails or the '/' of ratios. Fields are padded around the anchor, while
+// fields without it are aligned as if the anchor followed their content. Zero disables the alignment
+func (w *Writer) AlignOn(col int, anchor rune) {
+	spec := w.columnSpec(col)
+	spec.Anchor = anchor
+	w.SetColumnSpec(col, spec)
+}
+
+// anchorPosition returns the visible column where the given field's anchor is found, or the field's width if it
+// does not contain the anchor
+func (w *Writer) anchorPosition(field cell, anchor rune) int {
+	if i := strings.IndexRune(field.plain, anchor); i >= 0 {
+		return w.stringWidth(field.plain[:i])
+	}
+	return w.stringWidth(field.plain)
+}
+
+// alignAnchors pads the data fields of the columns aligned with [Writer.AlignOn] or with the [Decimal] alignment,
+// so that their anchors are displayed at the same position
+func (w *Writer) alignAnchors() {
+	if len(w.rows) < 2 {
+		return
+	}
+	columns := 0
+	for _, cells := range w.rows {
+		columns = max(columns, len(cells))
+	}
+	for c := range columns {
+		anchor := w.columnSpec(c).Anchor
+		if anchor == 0 && w.columnAlignment(c) == Decimal {
+			anchor = '.'
+		}
+		if anchor == 0 {
+			continue
+		}
+		before, after := 0, 0
+		for _, cells := range w.rows[1:] {
+			if c < len(cells) && cells[c].plain != "" {
+				pos := w.anchorPosition(cells[c], anchor)
+				before = max(before, pos)
+				after = max(after, w.stringWidth(cells[c].plain)-pos)
+			}
+		}
+		for _, cells := range w.rows[1:] {
+			if c >= len(cells) || cells[c].plain == "" {
+				continue
+			}
+			pos := w.anchorPosition(cells[c], anchor)
+			left := strings.Repeat(" ", before-pos)
+			right := strings.Repeat(" ", after-(w.stringWidth(cells[c].plain)-pos))
+			cells[c].text = left + cells[c].text + right
+			cells[c].plain = left + cells[c].plain + right
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package TableWriter
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Annotation defines the computed annotation appended to the numeric data fields of a column, which requires the
+// context of the whole column
+type Annotation uint
+
+const (
+	// AnnotateNone appends no annotation
+	AnnotateNone Annotation = iota
+	// AnnotateRank appends the rank of each value within the column (e.g. "#3"), where the largest one ranks first.
+	// Equal values share the same rank
+	AnnotateRank
+	// AnnotatePercentile appends the percentile of each value within the column (e.g. "p75"), which is the percentage
+	// of the column's values lower than or equal to it
+	AnnotatePercentile
+)
+
+// fieldNumber parses the numeric value leading the given field, ignoring what follows it (e.g. a trend arrow)
+func fieldNumber(s string) (float64, bool) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	return parseNumber(fields[0])
+}
+
+// annotateColumns appends to the numeric data fields of the columns using an [Annotation] their rank or percentile,
+// computed at render time from all the column's values. Non-numeric fields, NaN included, are left untouched
+func (w *Writer) annotateColumns() {
+	columns := 0
+	for _, cells := range w.rows {
+		columns = max(columns, len(cells))
+	}
+	for c := range columns {
+		annotation := w.columnSpec(c).Annotate
+		if annotation == AnnotateNone {
+			continue
+		}
+		values := make([]float64, 0, len(w.rows))
+		for _, cells := range w.rows[min(1, len(w.rows)):] {
+			if c >= len(cells) || w.isTemplateRow(cells) {
+				continue
+			}
+			if n, ok := fieldNumber(cells[c].plain); ok && !math.IsNaN(n) {
+				values = append(values, n)
+			}
+		}
+		// Values are sorted once, so that each field's position is found by a binary search
+		slices.Sort(values)
+
+		for _, cells := range w.rows[min(1, len(w.rows)):] {
+			if c >= len(cells) || w.isTemplateRow(cells) {
+				continue
+			}
+			n, ok := fieldNumber(cells[c].plain)
+			if !ok || math.IsNaN(n) {
+				continue
+			}
+			// Position of the first value greater than n, since no value compares as equal
+			lowerOrEqual, _ := slices.BinarySearchFunc(values, n, func(value, n float64) int {
+				if value <= n {
+					return -1
+				}
+				return 1
+			})
+			greater := len(values) - lowerOrEqual
+			label := "#" + strconv.Itoa(greater+1)
+			if annotation == AnnotatePercentile {
+				label = "p" + strconv.Itoa(int(math.Round(100*float64(lowerOrEqual)/float64(len(values)))))
+			}
+			cells[c].plain += " " + label
+			if w.flags&StripColours != 0 {
+				cells[c].text += " " + label
+			} else {
+				cells[c].text += " " + dimStart + label + styleEnd
+			}
+		}
+	}
+}
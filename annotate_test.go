@@ -0,0 +1,28 @@
+package TableWriter
+
+import "testing"
+
+func TestAnnotateColumns(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	got := renderTable(t, "A\tB\n3\t3\n1\t1\n3\t3\nx\tx\n2\t2\nNaN\tNaN\n", StripColours, WithWidth(40),
+		WithColumnSpec(0, ColumnSpec{Annotate: AnnotateRank}),
+		WithColumnSpec(1, ColumnSpec{Annotate: AnnotatePercentile}))
+	want := "┌─────┬───────┐\n" +
+		"│A    │B      │\n" +
+		"├─────┼───────┤\n" +
+		"│3 #1 │3 p100 │\n" +
+		"├─────┼───────┤\n" +
+		"│1 #4 │1 p25  │\n" +
+		"├─────┼───────┤\n" +
+		"│3 #1 │3 p100 │\n" +
+		"├─────┼───────┤\n" +
+		"│x    │x      │\n" +
+		"├─────┼───────┤\n" +
+		"│2 #3 │2 p50  │\n" +
+		"├─────┼───────┤\n" +
+		"│NaN  │NaN    │\n" +
+		"└─────┴───────┘\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package TableWriter
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Length and default prefix of the values replaced by [AnonymizeHash] and [AnonymizePseudonym]
+const (
+	anonymousHashLength = 8
+	defaultPseudonym    = "value"
+)
+
+// AnonymizeMode defines how the data fields of a column are replaced, in order to share tables containing
+// sensitive data (e.g. screenshots) while still showing which fields hold the same value
+type AnonymizeMode uint
+
+const (
+	// AnonymizeNone displays the fields as they are
+	AnonymizeNone AnonymizeMode = iota
+	// AnonymizeHash replaces the fields with a short hash of their value, which is the same across flushes and runs
+	AnonymizeHash
+	// AnonymizePseudonym replaces the fields with sequential pseudonyms (e.g. "user-1", "user-2"), assigned in order
+	// of appearance. See [ColumnSpec.Pseudonym]
+	AnonymizePseudonym
+)
+
+// anonymizeFields replaces the data fields of the columns using an [AnonymizeMode].
+// Pseudonyms are shared by all the columns using the same prefix and kept across flushes, so that the same value is
+// always replaced by the same pseudonym. Empty fields are never replaced
+func (w *Writer) anonymizeFields() {
+	for r := 1; r < len(w.rows); r++ {
+		for c := range w.rows[r] {
+			if anonymous, ok := w.anonymize(c, w.rows[r][c].plain); ok {
+				w.rows[r][c] = cell{text: anonymous, plain: anonymous}
+			}
+		}
+	}
+}
+
+// anonymize returns the value replacing the given colorless data field of the given column, reporting false when the
+// field is kept as it is
+func (w *Writer) anonymize(c int, value string) (string, bool) {
+	spec := w.columnSpec(c)
+	switch {
+	case spec.Anonymize == AnonymizeNone || value == "":
+		return "", false
+	case spec.Anonymize == AnonymizeHash:
+		sum := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(sum[:])[:anonymousHashLength], true
+	default:
+		return w.pseudonym(cmp.Or(spec.Pseudonym, defaultPseudonym), value), true
+	}
+}
+
+// pseudonym returns the pseudonym with the given prefix replacing the given value, assigning the next one in
+// sequence to the values that have never been seen before
+func (w *Writer) pseudonym(prefix string, value string) string {
+	if w.pseudonyms[prefix] == nil {
+		w.pseudonyms[prefix] = make(map[string]int)
+	}
+	n, ok := w.pseudonyms[prefix][value]
+	if !ok {
+		n = len(w.pseudonyms[prefix]) + 1
+		w.pseudonyms[prefix][value] = n
+	}
+	return prefix + "-" + strconv.Itoa(n)
+}
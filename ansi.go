@@ -0,0 +1,183 @@
+package TableWriter
+
+import (
+	"iter"
+	"strings"
+)
+
+// escapeSegments splits the given string into the plain text and the escape sequences it consists of, yielding each
+// segment along with whether it is an escape sequence. Escape sequences take no space on the terminal: they are
+// preserved in the fields and ignored when measuring them
+func escapeSegments(s string) iter.Seq2[string, bool] {
+	return func(yield func(string, bool) bool) {
+		for len(s) > 0 {
+			start := strings.IndexByte(s, '\x1b')
+			if start < 0 {
+				yield(s, false)
+				return
+			}
+			if start > 0 && !yield(s[:start], false) {
+				return
+			}
+			end := start + escapeLength(s[start:])
+			if !yield(s[start:end], true) {
+				return
+			}
+			s = s[end:]
+		}
+	}
+}
+
+// escapeLength returns the length of the escape sequence at the beginning of s, which starts with ESC.
+// The following sequences are recognized:
+// CSI: ESC [ followed by parameter and intermediate bytes and by a final byte (e.g. SGR colors and cursor movements)
+// OSC, DCS, SOS, PM and APC: ESC followed by ], P, X, ^ or _ and by a string terminated by ST (ESC \), or BEL for OSC
+// Others: ESC followed by intermediate bytes and by a final byte (e.g. ESC 7 or ESC ( B)
+// Malformed sequences end right before the first unexpected byte, while unterminated strings end before line breaks,
+// so that they never swallow the following rows
+func escapeLength(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			switch b := s[i]; {
+			case b >= 0x40 && b <= 0x7e:
+				return i + 1
+			case b < 0x20 || b > 0x3f:
+				return i
+			}
+		}
+		return len(s)
+	case ']', 'P', 'X', '^', '_':
+		for i := 2; i < len(s); i++ {
+			switch {
+			case s[i] == '\a' && s[1] == ']':
+				return i + 1
+			case s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\':
+				return i + 2
+			case s[i] == '\x1b' || s[i] == '\n':
+				return i
+			}
+		}
+		return len(s)
+	default:
+		i := 1
+		for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2f {
+			i++
+		}
+		if i < len(s) && s[i] >= 0x30 && s[i] <= 0x7e {
+			return i + 1
+		}
+		return i
+	}
+}
+
+// stripEscapeCodes removes all the escape sequences (ANSI color codes, terminal hyperlinks, cursor movements, etc.)
+// from the given string
+func stripEscapeCodes(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	var sb strings.Builder
+	for segment, escape := range escapeSegments(s) {
+		if !escape {
+			sb.WriteString(segment)
+		}
+	}
+	return sb.String()
+}
+
+// closeHyperlink closes the hyperlinks left open by the fields
+const closeHyperlink = "\033]8;;\033\\"
+
+// escapeState is the styling left open by a field: its active SGR sequences and its open hyperlink, if any.
+// The resulting style is tracked as well, so that only the attributes still in effect are reset, unless it sets some
+// unsupported attribute (e.g. blinking), requiring a full reset
+type escapeState struct {
+	sgr         string
+	style       sgrStyle
+	unsupported bool
+	link        string
+}
+
+// update applies the given escape sequence to the state
+func (s *escapeState) update(seq string) {
+	switch {
+	case strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m"):
+		params := seq[2 : len(seq)-1]
+		switch {
+		case params == "" || params == "0":
+			s.sgr, s.unsupported = "", false
+		case strings.HasPrefix(params, "0;"):
+			s.sgr, s.unsupported = seq, false
+		default:
+			s.sgr += seq
+		}
+		s.unsupported = !s.style.apply(seq) || s.unsupported
+		// Sequences turning off the attributes they set (e.g. bold followed by 22) leave nothing open
+		if !s.unsupported && s.style == (sgrStyle{}) {
+			s.sgr = ""
+		}
+	case strings.HasPrefix(seq, "\x1b]8;"):
+		// The URI follows the parameters, which are separated from it by a semicolon
+		_, uri, _ := strings.Cut(seq[len("\x1b]8;"):], ";")
+		uri = strings.TrimSuffix(strings.TrimSuffix(uri, "\a"), "\x1b\\")
+		s.link = ""
+		if uri != "" {
+			s.link = seq
+		}
+	}
+}
+
+// reset returns the SGR sequence turning off the attributes left open, which is a full reset when some of them are
+// unsupported
+func (s *escapeState) reset() string {
+	if s.unsupported {
+		return colorReset
+	}
+	params := make([]string, 0)
+	for _, attribute := range []struct {
+		set  bool
+		code string
+	}{
+		{s.style.bold || s.style.dim, "22"}, {s.style.italic, "23"}, {s.style.underline, "24"},
+		{s.style.inverse, "27"}, {s.style.strike, "29"}, {s.style.fg.set, "39"}, {s.style.bg.set, "49"},
+	} {
+		if attribute.set {
+			params = append(params, attribute.code)
+		}
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// closeEscapes prevents the styles and the hyperlinks left open by the given segments of a field from bleeding into
+// the padding, the borders and the following fields, by closing them at the end of each segment.
+// They are reopened at the beginning of the following segment, so that the field keeps its styling on every line
+func closeEscapes(segments []string) []string {
+	var state escapeState
+	for i, segment := range segments {
+		if !strings.Contains(segment, "\x1b") && state == (escapeState{}) {
+			continue
+		}
+		// Wrapped lines can already start with the styling of the field
+		if open := state.link + state.sgr; !strings.HasPrefix(segment, open) {
+			segment = open + segment
+		}
+		state = escapeState{}
+		for seq, escape := range escapeSegments(segment) {
+			if escape {
+				state.update(seq)
+			}
+		}
+		if state.sgr != "" {
+			segment += state.reset()
+		}
+		if state.link != "" {
+			segment += closeHyperlink
+		}
+		segments[i] = segment
+	}
+	return segments
+}
@@ -0,0 +1,71 @@
+package TableWriter
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestCloseEscapes(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments []string
+		want     []string
+	}{
+		{
+			name:     "plain text",
+			segments: []string{"abc", "def"},
+			want:     []string{"abc", "def"},
+		},
+		{
+			name:     "closed style",
+			segments: []string{"\x1b[1mabc\x1b[22m"},
+			want:     []string{"\x1b[1mabc\x1b[22m"},
+		},
+		{
+			name:     "open attributes",
+			segments: []string{"\x1b[1;4mabc"},
+			want:     []string{"\x1b[1;4mabc\x1b[22;24m"},
+		},
+		{
+			name:     "partially closed style",
+			segments: []string{"\x1b[1m\x1b[31mabc\x1b[22m"},
+			want:     []string{"\x1b[1m\x1b[31mabc\x1b[22m\x1b[39m"},
+		},
+		{
+			name:     "unsupported attribute",
+			segments: []string{"\x1b[5mabc"},
+			want:     []string{"\x1b[5mabc\x1b[0m"},
+		},
+		{
+			name:     "style reopened on the next line",
+			segments: []string{"\x1b[32mabc", "def\x1b[0m"},
+			want:     []string{"\x1b[32mabc\x1b[39m", "\x1b[32mdef\x1b[0m"},
+		},
+		{
+			name:     "open hyperlink",
+			segments: []string{"\x1b]8;;https://example.com\x1b\\abc"},
+			want:     []string{"\x1b]8;;https://example.com\x1b\\abc" + closeHyperlink},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := closeEscapes(slices.Clone(tt.segments)); !slices.Equal(got, tt.want) {
+				t.Errorf("closeEscapes(%q) = %q, want %q", tt.segments, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	w := NewWriter(io.Discard, 0)
+	_, _ = io.WriteString(w, "\x1b[1mname\x1b[22m\tid\n\x1b[31malice\t1\n")
+	err := w.Validate()
+	if !errors.Is(err, ErrUnclosedEscape) {
+		t.Fatalf("Validate() error = %v, want %v", err, ErrUnclosedEscape)
+	}
+	if want := `unclosed escape sequence: row 1, column 0 leaves "\x1b[31m" open`; err.Error() != want {
+		t.Errorf("Validate() error = %q, want %q", err, want)
+	}
+}
@@ -0,0 +1,63 @@
+package TableWriter
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// Terminal's size declared by recordings when the actual one is unknown
+const (
+	defaultCastCols = 80
+	defaultCastRows = 24
+)
+
+// asciicast records the output of the [Writer] as an asciinema v2 cast
+type asciicast struct {
+	out   io.Writer
+	start time.Time
+}
+
+// castHeader is the first line of an asciinema v2 cast
+type castHeader struct {
+	Version   int   `json:"version"`
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+// RecordAsciicast starts recording everything written to the output as an asciinema v2 cast, so that table-based
+// dashboards can be replayed and shared as terminal recordings. The recording replaces any previous one and lasts
+// until [Writer.Close] is called
+func (w *Writer) RecordAsciicast(out io.Writer) error {
+	w.cast = nil
+	start := time.Now()
+	header, err := json.Marshal(castHeader{
+		Version:   2,
+		Width:     cmp.Or(w.termCols, defaultCastCols),
+		Height:    cmp.Or(w.termRows, defaultCastRows),
+		Timestamp: start.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = out.Write(append(header, '\n')); err != nil {
+		return err
+	}
+	w.cast = &asciicast{out: out, start: start}
+	return nil
+}
+
+// record appends the given output to the recording as an event, timed from the start of the recording.
+// Line feeds are recorded as the terminal outputs them, preceded by a single carriage return
+func (c *asciicast) record(output []byte) error {
+	data := strings.ReplaceAll(strings.ReplaceAll(string(output), "\r\n", "\n"), "\n", "\r\n")
+	event, err := json.Marshal([]any{time.Since(c.start).Seconds(), "o", data})
+	if err != nil {
+		return err
+	}
+	_, err = c.out.Write(append(event, '\n'))
+	return err
+}
@@ -0,0 +1,42 @@
+package TableWriter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// asciidocEscaper escapes the cell separators contained in the fields of AsciiDoc tables
+var asciidocEscaper = strings.NewReplacer("|", `\|`)
+
+// renderAsciiDoc renders the parsed rows as an AsciiDoc table, whose first row is the header. All the rows have the
+// same number of columns, since AsciiDoc would otherwise flow the cells into the following rows, while the line
+// breaks of the fields become hard line breaks
+func (w *Writer) renderAsciiDoc() []byte {
+	rows := w.plainRows()
+	if len(rows) == 0 {
+		return nil
+	}
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`[cols="` + strconv.Itoa(columns) + `*",options="header"]` + "\n|===\n")
+	for r, row := range rows {
+		for c := range columns {
+			if c > 0 {
+				sb.WriteByte(' ')
+			}
+			field := asciidocEscaper.Replace(fieldAt(row, c))
+			sb.WriteString("|" + strings.ReplaceAll(field, "\n", " +\n"))
+		}
+		sb.WriteByte('\n')
+		// The blank line after the header separates it from the body
+		if r == 0 {
+			sb.WriteByte('\n')
+		}
+	}
+	sb.WriteString("|===\n")
+	return []byte(sb.String())
+}
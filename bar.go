@@ -0,0 +1,73 @@
+package TableWriter
+
+import (
+	"math"
+	"strings"
+)
+
+// defaultBarWidth is the natural width of the bars drawn by the columns using [ColumnSpec.Bar], which shrink along
+// with their column when the terminal is too narrow
+const defaultBarWidth = 20
+
+// Glyphs used to draw the bars, the partial ones filling eighths of a cell
+const (
+	barGlyph      = "█"
+	asciiBarGlyph = "#"
+)
+
+var partialBarGlyphs = [7]string{"▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// measureBar records the largest numeric value of the given column and the width of its widest numeric data field,
+// then widens the column to host the bars drawn next to them
+func (w *Writer) measureBar(c int) {
+	for _, cells := range w.rows[min(1, len(w.rows)):] {
+		if c >= len(cells) || w.isTemplateRow(cells) {
+			continue
+		}
+		if n, ok := fieldNumber(cells[c].plain); ok {
+			w.columns[c].barMax = max(w.columns[c].barMax, n)
+			w.columns[c].barLabel = max(w.columns[c].barLabel, w.fieldWidth(cells[c].plain))
+		}
+	}
+	if w.columns[c].barLabel > 0 {
+		w.columns[c].textWidth = max(w.columns[c].textWidth, w.columns[c].barLabel+1+defaultBarWidth)
+	}
+}
+
+// barField renders the given data field of a column using [ColumnSpec.Bar]: the value, right-aligned, followed by a
+// bar proportional to it that fills the rest of the column. Non-numeric fields, and the ones that no longer fit the
+// column, are rendered as usual
+func (w *Writer) barField(c int, field cell) (string, bool) {
+	col := w.columns[c]
+	if !w.columnSpec(c).Bar || col.barLabel == 0 {
+		return "", false
+	}
+	n, ok := fieldNumber(field.plain)
+	width := col.textWidth - col.barLabel - 1
+	if !ok || width <= 0 {
+		return "", false
+	}
+	fraction := 0.0
+	if col.barMax > 0 {
+		fraction = max(n, 0) / col.barMax
+	}
+	label := strings.Repeat(" ", col.barLabel-w.stringWidth(field.plain)) + field.text
+	return label + " " + w.bar(fraction, width), true
+}
+
+// bar draws a bar filling the given fraction of the given width, padded with spaces to the whole width.
+// Partial cells are drawn with eighth blocks, or rounded with [AsciiTable]
+func (w *Writer) bar(fraction float64, width int) string {
+	eighths := int(math.Round(min(fraction, 1) * float64(width) * 8))
+	if w.flags&AsciiTable != 0 {
+		full := (eighths + 4) / 8
+		return strings.Repeat(asciiBarGlyph, full) + strings.Repeat(" ", width-full)
+	}
+	full, partial := eighths/8, eighths%8
+	bar := strings.Repeat(w.glyph(barGlyph), full)
+	if partial > 0 {
+		bar += w.glyph(partialBarGlyphs[partial-1])
+		full++
+	}
+	return bar + strings.Repeat(" ", width-full)
+}
@@ -0,0 +1,59 @@
+package TableWriter
+
+import (
+	"errors"
+	"slices"
+	"unicode/utf8"
+)
+
+// ErrBinaryData is returned by [Writer.Write] when the received content looks like binary data.
+// The content is rejected as a whole, so that nothing is buffered. See [Writer.SetBinaryThreshold]
+var ErrBinaryData = errors.New("binary data received")
+
+// SetBinaryThreshold rejects the content written to the [Writer] whose fraction of NUL bytes and invalid UTF-8
+// sequences exceeds the given threshold, between 0 and 1. Binary data is then reported early by [ErrBinaryData],
+// instead of being buffered and later rendered as a broken table. Zero disables the check
+func (w *Writer) SetBinaryThreshold(threshold float64) {
+	w.binaryThreshold = min(max(threshold, 0), 1)
+}
+
+// WithBinaryThreshold rejects the content that looks like binary data. See [Writer.SetBinaryThreshold]
+func WithBinaryThreshold(threshold float64) Option {
+	return func(w *Writer) {
+		w.SetBinaryThreshold(threshold)
+	}
+}
+
+// isBinary reports whether the given content, appended to the buffered data, looks like binary data
+func (w *Writer) isBinary(buf []byte) bool {
+	if w.binaryThreshold == 0 || len(buf) == 0 {
+		return false
+	}
+
+	// Characters can be split across multiple writes, so the incomplete one ending the buffer is checked as well
+	tail := 0
+	for i := 1; i < utf8.UTFMax && i <= len(w.buffer); i++ {
+		if utf8.RuneStart(w.buffer[len(w.buffer)-i]) {
+			if !utf8.FullRune(w.buffer[len(w.buffer)-i:]) {
+				tail = i
+			}
+			break
+		}
+	}
+	data := append(slices.Clone(w.buffer[len(w.buffer)-tail:]), buf...)
+
+	suspicious := 0
+	for i := 0; i < len(data); {
+		r, size := utf8.DecodeRune(data[i:])
+		switch {
+		case r == utf8.RuneError && size == 1 && !utf8.FullRune(data[i:]):
+			// The last character could be completed by the next write
+			i = len(data)
+			continue
+		case r == 0, r == utf8.RuneError && size == 1:
+			suspicious++
+		}
+		i += size
+	}
+	return float64(suspicious)/float64(len(data)) > w.binaryThreshold
+}
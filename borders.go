@@ -0,0 +1,117 @@
+package TableWriter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Borders defines which of the table's borders and dividers are drawn, regardless of the characters drawing them
+type Borders uint
+
+const (
+	// BordersAll draws the outer border and all the dividers between rows and columns
+	BordersAll Borders = iota
+	// BordersNone draws no lines at all, rendering only padded and aligned columns separated by spaces, like
+	// [text/tabwriter]
+	BordersNone
+	// BordersOuter draws only the outer border and the line under the header, separating the columns with spaces
+	BordersOuter
+	// BordersHeader draws only the line under the header, separating the columns with spaces
+	BordersHeader
+	// BordersHeaderFrame draws only the line under the header and the top and bottom borders, separating the columns
+	// with spaces
+	BordersHeaderFrame
+)
+
+// SetBorders defines which of the table's borders and dividers are drawn, e.g. to render borderless columns where
+// box-drawing is unwanted
+func (w *Writer) SetBorders(borders Borders) {
+	w.borders = borders
+	w.initDividers()
+}
+
+// WithBorders defines which of the table's borders and dividers are drawn. See [Writer.SetBorders]
+func WithBorders(borders Borders) Option {
+	return func(w *Writer) {
+		w.borders = borders
+	}
+}
+
+// applyBorders replaces the vertical dividers that must not be drawn according to the selected [Borders]
+func (w *Writer) applyBorders() {
+	switch w.borders {
+	case BordersNone:
+		w.divider.VLine = " "
+		w.divider.OuterVLine = ""
+	case BordersOuter:
+		// Without inner vertical lines, the horizontal ones have no junctions
+		w.divider.VLine = " "
+		w.divider.Cross = w.divider.HLine
+		w.divider.TUp = w.divider.OuterHLine
+		w.divider.TDown = w.divider.OuterHLine
+	case BordersHeader, BordersHeaderFrame:
+		// Horizontal lines span the columns and the spaces between them only
+		w.divider.VLine = " "
+		w.divider.OuterVLine = ""
+		w.divider.Cross, w.divider.TUp, w.divider.TDown = w.divider.HLine, w.divider.OuterHLine, w.divider.OuterHLine
+		w.divider.TL, w.divider.TR, w.divider.BL, w.divider.BR, w.divider.VLeft, w.divider.VRight = "", "", "", "", "", ""
+	}
+}
+
+// drawsHLine reports whether the horizontal line above the row at the given index, or below the last row, must be
+// drawn according to the selected [Borders] and to the [Compact] flag
+func (w *Writer) drawsHLine(l int, isLastRow bool) bool {
+	if w.flags&Compact != 0 && l > 1 && !isLastRow && !w.isGuideRow(l-1+w.follow.rows, isLastRow) {
+		return false
+	}
+	switch w.borders {
+	case BordersNone:
+		return false
+	case BordersOuter, BordersHeaderFrame:
+		return l <= 1 || isLastRow
+	case BordersHeader:
+		return l == 1
+	default:
+		return true
+	}
+}
+
+// sgrSequence returns the SGR sequence made of the given parameters, or an empty string when there are none
+func sgrSequence(params string) (string, error) {
+	if strings.Trim(params, "0123456789;:") != "" {
+		return "", fmt.Errorf("invalid SGR parameters %q", params)
+	}
+	if params == "" {
+		return "", nil
+	}
+	return "\033[" + params + "m", nil
+}
+
+// SetBorderColor draws the table's borders and dividers with the given SGR parameters (e.g. "2" for dim lines, "90"
+// for gray ones or "38;5;240"), leaving the fields' colors intact. Empty parameters restore the terminal's default
+// color. Borders are never colored when [StripColours] is set
+func (w *Writer) SetBorderColor(params string) error {
+	sequence, err := sgrSequence(params)
+	if err != nil {
+		return err
+	}
+	w.borderColor = sequence
+	return nil
+}
+
+// WithBorderColor draws the table's borders and dividers with the given SGR parameters.
+// Invalid parameters are ignored. See [Writer.SetBorderColor]
+func WithBorderColor(params string) Option {
+	return func(w *Writer) {
+		_ = w.SetBorderColor(params)
+	}
+}
+
+// colorBorder colors the given divider, or line of dividers, according to [Writer.SetBorderColor]. Blank dividers
+// are left untouched, since they would only add escape sequences
+func (w *Writer) colorBorder(divider string) string {
+	if w.borderColor == "" || w.flags&StripColours != 0 || strings.TrimSpace(divider) == "" {
+		return divider
+	}
+	return w.borderColor + divider + colorReset
+}
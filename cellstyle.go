@@ -0,0 +1,136 @@
+package TableWriter
+
+import (
+	"cmp"
+	"strings"
+)
+
+// Attribute is a text attribute of a [CellStyle], which is either set, unset or inherited from the enclosing level
+type Attribute uint8
+
+const (
+	// AttributeInherit keeps the attribute of the enclosing level
+	AttributeInherit Attribute = iota
+	// AttributeOn sets the attribute
+	AttributeOn
+	// AttributeOff unsets the attribute, even if the enclosing level sets it
+	AttributeOff
+)
+
+// CellStyle defines the colors and the attributes of the fields' text. Styles are resolved by cascading the table's
+// style, the column's one, the row's one and the cell's one, in this order: each level overrides only the colors and
+// the attributes it sets, inheriting all the others. The escape codes within the fields take precedence over them
+type CellStyle struct {
+	// Foreground holds the SGR parameters of the text's color, e.g. "31" or "38;5;208". Empty inherits the color
+	Foreground string
+	// Background holds the SGR parameters of the background's color, e.g. "44" or "48;5;236".
+	// Empty inherits the color
+	Background string
+	// Bold, Dim, Italic and Underline set or unset the text's attributes
+	Bold      Attribute
+	Dim       Attribute
+	Italic    Attribute
+	Underline Attribute
+}
+
+// override returns the style resulting from overriding s with the colors and the attributes set by the given style
+func (s CellStyle) override(o CellStyle) CellStyle {
+	s.Foreground = cmp.Or(o.Foreground, s.Foreground)
+	s.Background = cmp.Or(o.Background, s.Background)
+	for _, attribute := range []struct{ base, override *Attribute }{
+		{&s.Bold, &o.Bold}, {&s.Dim, &o.Dim}, {&s.Italic, &o.Italic}, {&s.Underline, &o.Underline},
+	} {
+		if *attribute.override != AttributeInherit {
+			*attribute.base = *attribute.override
+		}
+	}
+	return s
+}
+
+// sgr returns the SGR sequence applying the style, or an empty string if it sets nothing.
+// Invalid colors are ignored
+func (s CellStyle) sgr() string {
+	params := make([]string, 0)
+	for _, attribute := range []struct {
+		value Attribute
+		code  string
+	}{{s.Bold, "1"}, {s.Dim, "2"}, {s.Italic, "3"}, {s.Underline, "4"}} {
+		if attribute.value == AttributeOn {
+			params = append(params, attribute.code)
+		}
+	}
+	for _, color := range []string{s.Foreground, s.Background} {
+		if sequence, err := sgrSequence(color); err == nil && sequence != "" {
+			params = append(params, color)
+		}
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\033[" + strings.Join(params, ";") + "m"
+}
+
+// SetDefaultCellStyle defines the table-level style of the fields, which is the first level of the cascade.
+// See [CellStyle]
+func (w *Writer) SetDefaultCellStyle(style CellStyle) {
+	w.cellStyle = style
+}
+
+// WithDefaultCellStyle defines the table-level style of the fields. See [Writer.SetDefaultCellStyle]
+func WithDefaultCellStyle(style CellStyle) Option {
+	return func(w *Writer) {
+		w.SetDefaultCellStyle(style)
+	}
+}
+
+// SetRowStyle defines the style of the fields of the row at the given index, which overrides the columns' styles.
+// Rows are numbered as they are received, starting from the header at index 0. See [CellStyle]
+func (w *Writer) SetRowStyle(row int, style CellStyle) {
+	w.rowStyles[row] = style
+}
+
+// SetCellStyle defines the style of the field at the given row and column, which overrides all the other levels.
+// Rows are numbered as they are received, starting from the header at index 0. See [CellStyle]
+func (w *Writer) SetCellStyle(row, col int, style CellStyle) {
+	w.cellStyles[[2]int{row, col}] = style
+}
+
+// EffectiveCellStyle returns the style applied to the field at the given row and column, as resolved by cascading
+// the table's style, the column's one (see [ColumnSpec]), the row's one and the cell's one
+func (w *Writer) EffectiveCellStyle(row, col int) CellStyle {
+	style := w.cellStyle.override(w.columnSpec(col).Style).override(w.rowStyles[row])
+	return style.override(w.cellStyles[[2]int{row, col}])
+}
+
+// applyCellStyles applies the effective style of each field to its text. The style is restored after the field's own
+// resets, so that its escape codes override the style only where they are in effect
+func (w *Writer) applyCellStyles() {
+	if w.flags&StripColours != 0 {
+		return
+	}
+	for r, cells := range w.rows {
+		// The header of followed tables has already been styled by the first flush
+		if (r == 0 && w.isFollowing()) || w.isTemplateRow(cells) {
+			continue
+		}
+		row := r
+		if r > 0 {
+			row += w.follow.rows
+		}
+		for c := range cells {
+			sgr := w.EffectiveCellStyle(row, c).sgr()
+			if sgr == "" || cells[c].text == "" {
+				continue
+			}
+			var sb strings.Builder
+			sb.WriteString(sgr)
+			for segment, escape := range escapeSegments(cells[c].text) {
+				sb.WriteString(segment)
+				if escape && (segment == "\x1b[0m" || segment == "\x1b[m") {
+					sb.WriteString(sgr)
+				}
+			}
+			cells[c].text = sb.String()
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package TableWriter
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// Clone returns a new [Writer] sharing the configuration of the current one, with the given options applied on top
+// of it. The buffered data and its footnotes are copied as well, so that the same data can be rendered with different
+// styles without ingesting it again: call [Writer.Clear] on the clone to start from an empty buffer instead.
+// The state of the rendered tables (follow mode, compared rows, history and recordings) is not copied
+func (w *Writer) Clone(opts ...Option) *Writer {
+	clone := new(Writer)
+	*clone = *w
+	clone.columnSpecs = maps.Clone(w.columnSpecs)
+	clone.renames = maps.Clone(w.renames)
+	clone.metadata = maps.Clone(w.metadata)
+	clone.presets = maps.Clone(w.presets)
+	clone.sanitizePolicy = maps.Clone(w.sanitizePolicy)
+	clone.rowTemplates = maps.Clone(w.rowTemplates)
+	clone.rowStyles = maps.Clone(w.rowStyles)
+	clone.cellStyles = maps.Clone(w.cellStyles)
+	clone.groups = slices.Clone(w.groups)
+	clone.fixedWidths = slices.Clone(w.fixedWidths)
+	clone.profiles = slices.Clone(w.profiles)
+	// Pseudonyms are copied, so that the same data is anonymized in the same way by both Writers
+	clone.pseudonyms = make(map[string]map[string]int, len(w.pseudonyms))
+	for prefix, values := range w.pseudonyms {
+		clone.pseudonyms[prefix] = maps.Clone(values)
+	}
+
+	clone.follow = followState{}
+	clone.stats = RenderStats{}
+	clone.lastRender = time.Time{}
+	clone.written = 0
+	clone.altScreen = false
+	clone.resize = nil
+	clone.truncated = nil
+	clone.layout = nil
+	clone.previous = nil
+	clone.history = history{frames: make([][]byte, len(w.history.frames))}
+	clone.cast = nil
+	clone.Clear()
+	clone.buffer = slices.Clone(w.buffer)
+	clone.lazyRows = maps.Clone(w.lazyRows)
+	clone.footnotes = slices.Clone(w.footnotes)
+
+	for _, opt := range opts {
+		opt(clone)
+	}
+	clone.initDividers()
+	clone.measureTerminal()
+	return clone
+}
@@ -0,0 +1,39 @@
+package TableWriter
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestCloneRendersTheSameTable(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	var original, cloned bytes.Buffer
+	w := NewWriter(&original, StripColours, WithWidth(40), WithColumnSpec(1, ColumnSpec{Ditto: DittoMark}))
+	if _, err := io.WriteString(w, "Name\tTeam\nAlice\tred\nBob\tred\n"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.AppendRow("Carol", func() string { return "blue" }); err != nil {
+		t.Fatalf("AppendRow() error = %v", err)
+	}
+	w.AddFootnote(1, 0, "team lead")
+	w.SetMetadata("Unit", "MB")
+
+	clone := w.Clone()
+	clone.output = &cloned
+	// Footnotes added after cloning belong to a single Writer
+	clone.AddFootnote(2, 0, "on leave")
+	w.AddFootnote(2, 0, "on leave")
+	for _, writer := range []*Writer{w, clone} {
+		if err := writer.Flush(); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+	}
+
+	if cloned.String() != original.String() {
+		t.Errorf("clone rendered:\n%s\nwant:\n%s", cloned.String(), original.String())
+	}
+	if want := "¹ team lead\n² on leave\n"; !bytes.HasSuffix(cloned.Bytes(), []byte(want)) {
+		t.Errorf("clone rendered:\n%s\nwant the footnote %q", cloned.String(), want)
+	}
+}
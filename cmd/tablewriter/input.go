@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Scrayil/TableWriter"
+)
+
+// Supported input formats
+const (
+	formatAuto = "auto"
+	formatTSV  = "tsv"
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+// detectFormat guesses the format of the given input by looking at its first characters and its first line
+func detectFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return formatJSON
+	}
+	firstLine, _, _ := bytes.Cut(trimmed, []byte{'\n'})
+	if !bytes.ContainsRune(firstLine, '\t') && bytes.ContainsRune(firstLine, ',') {
+		return formatCSV
+	}
+	return formatTSV
+}
+
+// readRows parses the given input according to the specified format and returns its rows
+func readRows(data []byte, format string) ([][]string, error) {
+	if format == formatAuto {
+		format = detectFormat(data)
+	}
+	switch format {
+	case formatTSV:
+		rows := make([][]string, 0)
+		for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+			rows = append(rows, strings.Split(strings.TrimSuffix(line, "\r"), "\t"))
+		}
+		return rows, nil
+	case formatCSV:
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.FieldsPerRecord = -1
+		return reader.ReadAll()
+	case formatJSON:
+		return readJSONRows(data)
+	default:
+		return nil, fmt.Errorf("unsupported input format %q", format)
+	}
+}
+
+// readJSONRows parses either an array of objects, whose keys become the table's header, or an array of arrays.
+// A single object is treated as an array containing only that object
+func readJSONRows(data []byte) ([][]string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		data = append(append([]byte{'['}, data...), ']')
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(items)+1)
+	var header []string
+	columns := make(map[string]int)
+	for _, item := range items {
+		item = bytes.TrimSpace(item)
+		if len(item) == 0 || item[0] != '{' {
+			var values []json.RawMessage
+			if err := json.Unmarshal(item, &values); err != nil {
+				return nil, fmt.Errorf("expected an array of objects or an array of arrays: %w", err)
+			}
+			row := make([]string, len(values))
+			for i, value := range values {
+				row[i] = jsonCell(value)
+			}
+			rows = append(rows, row)
+			continue
+		}
+
+		keys, values, err := orderedObject(item)
+		if err != nil {
+			return nil, err
+		}
+		row := make([]string, len(header))
+		for i, key := range keys {
+			c, ok := columns[key]
+			if !ok {
+				c = len(header)
+				columns[key] = c
+				header = append(header, key)
+				row = append(row, "")
+			}
+			row[c] = jsonCell(values[i])
+		}
+		rows = append(rows, row)
+	}
+	if header != nil {
+		rows = append([][]string{header}, rows...)
+	}
+	return rows, nil
+}
+
+// orderedObject decodes a JSON object, preserving the order of its keys
+func orderedObject(data []byte) ([]string, []json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, err
+	}
+	keys := make([]string, 0)
+	values := make([]json.RawMessage, 0)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		var value json.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, token.(string))
+		values = append(values, value)
+	}
+	return keys, values, nil
+}
+
+// jsonCell converts a JSON value into the text displayed in a table's field.
+// Strings are unquoted, null becomes an empty field and any other value is kept in its compact JSON form
+func jsonCell(value json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+	if string(value) == "null" {
+		return ""
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, value); err != nil {
+		return string(value)
+	}
+	return compact.String()
+}
+
+// writeRows sends the given rows to the table writer, escaping the characters that would break the table's layout
+func writeRows(w io.Writer, rows [][]string) error {
+	for _, row := range rows {
+		for i := range row {
+			row[i] = TableWriter.EscapeCell(row[i])
+		}
+		if _, err := io.WriteString(w, strings.Join(row, "\t")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
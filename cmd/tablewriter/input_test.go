@@ -0,0 +1,62 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "empty", input: "", want: formatTSV},
+		{name: "tsv", input: "a\tb\n1\t2\n", want: formatTSV},
+		{name: "tsv with commas", input: "a,b\tc\n", want: formatTSV},
+		{name: "csv", input: "a,b\n1,2\n", want: formatCSV},
+		{name: "csv after blank lines", input: "\n\n a,b\n", want: formatCSV},
+		{name: "json array", input: "  [[1, 2]]", want: formatJSON},
+		{name: "json object", input: "\n{\"a\": 1}", want: formatJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectFormat([]byte(tt.input)); got != tt.want {
+				t.Errorf("detectFormat(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadRows(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  [][]string
+	}{
+		{name: "tsv", input: "a\tb\r\n1\t2\n\n", want: [][]string{{"a", "b"}, {"1", "2"}}},
+		{name: "csv", input: "a,b\n\"x,y\",\"line\nbreak\"\n3\n", want: [][]string{{"a", "b"}, {"x,y", "line\nbreak"}, {"3"}}},
+		{
+			name:  "json objects",
+			input: `[{"b": "x", "a": null}, {"c": [1, 2], "a": true}]`,
+			want:  [][]string{{"b", "a", "c"}, {"x", ""}, {"", "true", "[1,2]"}},
+		},
+		{name: "json arrays", input: `[["a", 1.5], ["b"]]`, want: [][]string{{"a", "1.5"}, {"b"}}},
+		{name: "json object", input: `{"a": "1"}`, want: [][]string{{"a"}, {"1"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readRows([]byte(tt.input), formatAuto)
+			if err != nil {
+				t.Fatalf("readRows() error = %v", err)
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("readRows() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := readRows([]byte("a\n"), "xml"); err == nil {
+		t.Error("readRows() accepted an unsupported format")
+	}
+}
@@ -0,0 +1,124 @@
+// Command tablewriter reads tabular data from the standard input and prints it as a formatted table.
+//
+// The input can be tab-separated (TSV), comma-separated (CSV) or JSON (an array of objects or an array of arrays).
+// Its format is automatically detected, unless specified with the -format option.
+//
+// All the other options are forwarded to [TableWriter.OptionsFromArgs], so their names and values are the same
+// accepted by the library.
+//
+// Usage:
+//
+//	kubectl get pods -o json | jq '.items | map({name: .metadata.name, phase: .status.phase})' | tablewriter
+//	tablewriter -format csv -align right < report.csv
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Scrayil/TableWriter"
+)
+
+// tableOptions lists the library's options exposed as command line flags, along with their descriptions.
+// The responsive setting is excluded, since it selects a preset that can only be defined in code, and so is
+// alt-screen, as the single table rendered by the command would vanish along with the alternate screen
+var tableOptions = []struct {
+	name    string
+	usage   string
+	boolean bool
+}{
+	{"align", "fields alignment: left, middle or right", false},
+	{"table-align", "table position within the terminal: left, center or right", false},
+	{"style", "borders' characters: default, rounded, double, heavy, dotted or minimal", false},
+	{"borders", "borders and dividers drawn: all, none, outer, header or header-frame", false},
+	{"border-color", "SGR parameters coloring the borders, e.g. 90 for gray", false},
+	{"frame", "outer border emphasis: default, double or shadow", false},
+	{"truncate", "policy for fields exceeding the terminal width: cut, middle, wrap, hide or never", false},
+	{"summary", "statistic appended to each header: none, count or unique", false},
+	{"anonymize", "replacement of the data fields: none, hash or pseudonym", false},
+	{"annotate", "annotation appended to the numeric data fields: none, rank or percentile", false},
+	{"sanitize", "handling of a Unicode category's invisible characters, e.g. Zs:keep (keep, strip or replace)", false},
+	{"output", "output format: table, html, csv, tsv, json, json-arrays, json-meta, rst, asciidoc or jira", false},
+	{"export-encoding", "encoding of the exported formats: utf-8, utf-8-bom, utf-16le, latin1 or windows-1252", false},
+	{"line-ending", "sequence terminating the output lines: lf or crlf", false},
+	{"carriage-return", "handling of bare carriage returns: strip, reset or split", false},
+	{"emoji-width", "cells used to display emoji: auto, narrow or wide", false},
+	{"ambiguous-width", "cells used to display East Asian ambiguous characters: auto, narrow or wide", false},
+	{"width", "width used to lay out the table, regardless of the terminal's one", false},
+	{"height", "height of the terminal, used to repeat the header of followed tables", false},
+	{"default-width", "width used when the terminal's one is unknown (0 is unlimited)", false},
+	{"max-buffer-size", "maximum amount of input bytes (0 means unlimited)", false},
+	{"binary-threshold", "maximum fraction of NUL bytes and invalid UTF-8 in the input (0 disables the check)", false},
+	{"column-widths", "colon-separated widths forced on the columns, e.g. 10:0:20 (0 leaves a column's width automatic)", false},
+	{"max-row-lines", "maximum number of lines per row when wrapping fields (0 means unlimited)", false},
+	{"align-numbers", "right-align the columns whose values are all numeric", true},
+	{"highlight-changes", "color the fields whose value changed since the previous flush, matching the rows by key", true},
+	{"keep-marks", "preserve combining marks instead of removing them", true},
+	{"legend", "render a legend explaining the colors applied to the table", true},
+	{"markup", "translate **bold**, _dim_ and `code` markup into ANSI styles", true},
+	{"strip-colours", "remove ANSI color codes from the output", true},
+	{"auto-strip-colours", "remove ANSI color codes when the output is not a terminal", true},
+	{"tty-fallback", "measure the controlling terminal when the output is redirected", true},
+	{"compact", "drop the horizontal lines between data rows", true},
+	{"remove-least-pad", "remove the minimum padding between fields and borders", true},
+	{"preserve-long-fields", "never truncate long fields", true},
+	{"ascii", "use only ASCII characters for the table's borders", true},
+	{"mark-wrapped", "prefix the continuation lines of wrapped fields with a marker", true},
+	{"drop-trailing-tab", "ignore a single tab at the end of each line instead of opening an empty field", true},
+	{"copy-friendly", "render vertical borders as spaces, so rows can be copied without border characters", true},
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		fmt.Fprintln(os.Stderr, "tablewriter:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the command line arguments, reads the input and renders it as a table
+func run(args []string, input io.Reader, output io.Writer) error {
+	fs := flag.NewFlagSet("tablewriter", flag.ContinueOnError)
+	format := fs.String("format", formatAuto, "input format: auto, tsv, csv or json")
+	// Templates can contain commas, so they cannot be forwarded as comma-separated settings
+	summaryLine := fs.String("summary-line", "", "template of the line rendered below the table, e.g. \"{{.Rows}} rows\"")
+	settings := make([]string, 0)
+	for _, option := range tableOptions {
+		collect := func(value string) error {
+			settings = append(settings, option.name+"="+value)
+			return nil
+		}
+		if option.boolean {
+			fs.BoolFunc(option.name, option.usage, collect)
+		} else {
+			fs.Func(option.name, option.usage, collect)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	opts, err := TableWriter.OptionsFromArgs(settings)
+	if err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+	rows, err := readRows(data, *format)
+	if err != nil {
+		return err
+	}
+
+	w := TableWriter.NewWriter(output, 0, append(opts, TableWriter.WithSummaryLine(*summaryLine))...)
+	if err = writeRows(w, rows); err != nil {
+		return err
+	}
+	return w.Close()
+}
@@ -1,7 +1,37 @@
 package TableWriter
 
+import "os"
+
 // ANSI Color Codes for readable CLI output.
 var (
 	colorReset  = "\033[0m"
 	colorOrange = "\033[38;5;208m"
+	colorYellow = "\033[33m"
+	colorGreen  = "\033[32m"
+	colorRed    = "\033[31m"
 )
+
+// colorEnvironment reports the user's preference about colors expressed by the NO_COLOR and FORCE_COLOR environment
+// variables, if any. FORCE_COLOR takes precedence and disables colors when set to "0" or "false"
+func colorEnvironment() (enabled bool, set bool) {
+	if force := os.Getenv("FORCE_COLOR"); force != "" {
+		return force != "0" && force != "false", true
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false, true
+	}
+	return false, false
+}
+
+// applyColorEnvironment sets [StripColours] when colors are disabled by the environment, or when
+// [AutoStripColours] is set and the output is not a terminal, unless colors are forced by the environment
+func (w *Writer) applyColorEnvironment() {
+	enabled, set := colorEnvironment()
+	switch {
+	case set && !enabled:
+		w.flags |= StripColours
+	case !set && w.flags&AutoStripColours != 0 && !w.isTerminal():
+		w.debug("colors stripped", "reason", "output is not a terminal")
+		w.flags |= StripColours
+	}
+}
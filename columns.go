@@ -0,0 +1,278 @@
+package TableWriter
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Suffixes appended to the fields that have been cut, in order to signal that some content is missing.
+// Short suffixes are used by columns too narrow to host the default one
+const (
+	truncationSuffix           = "[...]"
+	shortTruncationSuffix      = "…"
+	asciiShortTruncationSuffix = "~"
+)
+
+// minColumnWidth is the width under which columns are never shrunk to fit the terminal, unless their content is
+// narrower. When the terminal is too narrow to host all the columns at this width, the table overflows it
+const minColumnWidth = 3
+
+// TruncatePolicy defines how the fields of a column are processed when they exceed the column's width budget
+type TruncatePolicy uint
+
+const (
+	// TruncateCut cuts the exceeding tail of the field and appends a suffix indicating that the output has been truncated
+	TruncateCut TruncatePolicy = iota
+	// TruncateMiddle cuts the central part of the field, preserving both its beginning and its end
+	TruncateMiddle
+	// TruncateWrap splits the field over multiple lines, so that no content is lost
+	TruncateWrap
+	// TruncateHide removes the whole column from the table when its fields cannot fit
+	TruncateHide
+	// TruncateNever always renders the column's fields in full, taking the required space from the other columns.
+	// Useful for identifiers, which are useless when truncated
+	TruncateNever
+)
+
+// String returns the name of the policy
+func (p TruncatePolicy) String() string {
+	switch p {
+	case TruncateCut:
+		return "cut"
+	case TruncateMiddle:
+		return "middle"
+	case TruncateWrap:
+		return "wrap"
+	case TruncateHide:
+		return "hide"
+	case TruncateNever:
+		return "never"
+	default:
+		return "unknown"
+	}
+}
+
+// ColumnSpec holds the configuration of a single table's column
+type ColumnSpec struct {
+	// Truncate is the policy applied to the column's fields that exceed the available space
+	Truncate TruncatePolicy
+	// Summary is the statistic appended to the column's header
+	Summary ColumnSummary
+	// Copy defines how the column's values are made available for copying
+	Copy CopyMode
+	// Align overrides the alignment of the column's fields, which otherwise follows the [AlignMiddle] and
+	// [AlignRight] flags
+	Align Alignment
+	// Anchor aligns the column's data fields on the first occurrence of the given character.
+	// See [Writer.AlignOn]
+	Anchor rune
+	// MaxWidth limits the column's width, regardless of the terminal's one. Fields exceeding it are processed
+	// according to the Truncate policy. Zero means unlimited
+	MaxWidth int
+	// FoldOn hides the leading segments, delimited by the given separator (e.g. '/' for paths or '.' for dotted
+	// names), that each data field shares with the one directly above it. Zero disables the folding
+	FoldOn rune
+	// Ditto replaces the data fields identical to the ones directly above them with a ditto mark or a blank
+	Ditto DittoMode
+	// Trend appends to the column's numeric values an arrow (▲, ▼ or =) comparing them with the previous values of
+	// the same rows, matched by their key. See [Writer.SetRowKey]
+	Trend bool
+	// Anonymize replaces the column's data fields with short hashes or sequential pseudonyms, so that tables holding
+	// sensitive data can be shared while still showing which fields match. Output formats and exports are anonymized
+	// as well
+	Anonymize AnonymizeMode
+	// Pseudonym is the prefix of the pseudonyms used by [AnonymizePseudonym] ("value" by default). Columns sharing
+	// the same prefix replace the same values with the same pseudonyms, so that they can still be joined
+	Pseudonym string
+	// Annotate appends to the column's numeric data fields an annotation computed from all its values, like their
+	// rank or percentile
+	Annotate Annotation
+	// Bar draws next to the column's numeric data fields a horizontal bar proportional to their value, scaled to the
+	// column's width and to the largest value
+	Bar bool
+	// Percent colors the column's numeric data fields by their deviation from a target range and draws a gauge next
+	// to them. Nil disables the formatting
+	Percent *PercentSpec
+	// Style is the column-level style of the fields, which overrides the table's style. See [CellStyle]
+	Style CellStyle
+	// PadLeft and PadRight are the characters filling the space on each side of the column's data fields (e.g. '.'
+	// for dot leaders or '\u2007' for figure spaces), while the space separating the fields from the borders is kept.
+	// Zero, or characters not exactly one cell wide, mean spaces
+	PadLeft  rune
+	PadRight rune
+}
+
+// SetColumnSpec configures the column at the given index.
+// Columns that have not been configured behave as specified by the default [ColumnSpec]
+func (w *Writer) SetColumnSpec(col int, spec ColumnSpec) {
+	w.columnSpecs[col] = spec
+}
+
+// SetDefaultColumnSpec defines the configuration of all the columns that have not been configured with
+// [Writer.SetColumnSpec]. Unless changed, the zero [ColumnSpec] is used
+func (w *Writer) SetDefaultColumnSpec(spec ColumnSpec) {
+	w.defaultSpec = spec
+}
+
+// columnSpec returns the configuration of the column at the given index
+func (w *Writer) columnSpec(col int) ColumnSpec {
+	if spec, ok := w.columnSpecs[col]; ok {
+		return spec
+	}
+	return w.defaultSpec
+}
+
+// columnAlignment returns the alignment of the fields of the column at the given index.
+// Columns without a specific alignment follow the [AlignNumbers], [AlignMiddle] and [AlignRight] flags
+func (w *Writer) columnAlignment(c int) Alignment {
+	if align := w.columnSpec(c).Align; align != AlignDefault {
+		return align
+	}
+	switch {
+	case w.flags&AlignNumbers != 0 && c < len(w.columns) && w.columns[c].numeric:
+		return Right
+	case w.flags&AlignMiddle != 0:
+		return Center
+	case w.flags&AlignRight != 0:
+		return Right
+	default:
+		return Left
+	}
+}
+
+// leastPadding returns the minimum amount of spaces used to separate the fields of the column at the given index
+// from the nearby columns
+func (w *Writer) leastPadding(c int) int {
+	if w.flags&RemoveLeastPad != 0 {
+		return 0
+	}
+	if w.columnAlignment(c) == Center {
+		return 2
+	}
+	return 1
+}
+
+// columnBudgets distributes the terminal's width among the visible columns.
+// Columns using the [TruncateNever] policy and narrow columns are granted their whole width, while the remaining
+// space is evenly shared among the wider ones
+func (w *Writer) columnBudgets() []int {
+	flexible := make([]int, 0, len(w.columns))
+	budgets := make([]int, len(w.columns))
+	// Each column requires its own padding and right border, while the whole table requires a left border
+	available := w.termCols - 1
+	for c := range w.columns {
+		if w.columns[c].hidden {
+			continue
+		}
+		available -= w.leastPadding(c) + 1
+		if w.columnSpec(c).Truncate == TruncateNever {
+			budgets[c] = w.columns[c].textWidth
+			available -= budgets[c]
+		} else {
+			flexible = append(flexible, c)
+		}
+	}
+	slices.SortStableFunc(flexible, func(a, b int) int {
+		return cmp.Compare(w.columns[a].textWidth, w.columns[b].textWidth)
+	})
+
+	for i, c := range flexible {
+		share := max(available/(len(flexible)-i), minColumnWidth)
+		budgets[c] = min(w.columns[c].textWidth, share)
+		available -= budgets[c]
+	}
+	return budgets
+}
+
+// fitColumns shrinks the columns that do not fit in the terminal, according to their width budgets.
+// Columns using the [TruncateHide] policy are removed instead, and their space is shared among the remaining ones.
+// The resulting layout is finally submitted to the [LayoutNegotiator], if any
+func (w *Writer) fitColumns() {
+	if w.flags&PreserveLongFields != 0 || w.termCols <= 0 {
+		w.debug("columns not fitted to the terminal", "preserve_long_fields", w.flags&PreserveLongFields != 0,
+			"terminal_cols", w.termCols)
+		return
+	}
+	for {
+		budgets := w.columnBudgets()
+		hidden := false
+		for c := range w.columns {
+			if !w.columns[c].hidden && w.columns[c].textWidth > budgets[c] && w.columnSpec(c).Truncate == TruncateHide {
+				w.columns[c].hidden = true
+				hidden = true
+				w.debug("column dropped", "col", c, "width", w.columns[c].textWidth, "budget", budgets[c],
+					"reason", "truncate policy")
+				w.emit(ColumnDropped{Col: c})
+			}
+		}
+		if !hidden {
+			budgets = w.negotiateLayout(budgets)
+			for c := range w.columns {
+				w.columns[c].textWidth = min(w.columns[c].textWidth, budgets[c])
+			}
+			return
+		}
+	}
+}
+
+// truncationMarker returns the suffix used to signal truncated fields, colored unless [StripColours] is set, along
+// with its width. Fields too narrow to host the whole suffix use a single-character ellipsis instead
+func (w *Writer) truncationMarker(maxWidth int) (string, int) {
+	marker := truncationSuffix
+	if maxWidth <= w.stringWidth(truncationSuffix) {
+		marker = w.glyph(shortTruncationSuffix)
+		if w.flags&AsciiTable != 0 {
+			marker = asciiShortTruncationSuffix
+		}
+	}
+	if w.flags&StripColours != 0 {
+		return marker, w.stringWidth(marker)
+	}
+	w.useLegendEntry(legendTruncated)
+	return colorOrange + marker + colorReset, w.stringWidth(marker)
+}
+
+// cutField keeps the beginning of the given text followed by the truncation marker, within maxWidth visible columns.
+// Single-character fields cannot host any marker, so they are simply cut
+func (w *Writer) cutField(text string, maxWidth int) string {
+	marker, markerWidth := w.truncationMarker(maxWidth)
+	if maxWidth <= markerWidth {
+		return w.sliceVisible(text, 0, maxWidth)
+	}
+	return w.sliceVisible(text, 0, maxWidth-markerWidth) + marker
+}
+
+// truncateField processes the given field according to its column's [TruncatePolicy], when exceeding the column's
+// width. The resulting segments are returned, one for each physical line the field spans over
+func (w *Writer) truncateField(c int, field cell) []string {
+	// Fields containing line breaks are processed one line at a time
+	if strings.Contains(field.plain, "\n") {
+		segments := make([]string, 0)
+		for line := range strings.Lines(field.text) {
+			line = strings.TrimSuffix(line, "\n")
+			segments = append(segments, w.truncateField(c, cell{text: line, plain: stripEscapeCodes(line)})...)
+		}
+		return segments
+	}
+	width := w.stringWidth(field.plain)
+	maxWidth := w.columns[c].textWidth
+	if width <= maxWidth {
+		return []string{field.text}
+	}
+
+	switch w.columnSpec(c).Truncate {
+	case TruncateWrap:
+		return w.limitRowLines(w.wrapField(field, maxWidth), maxWidth)
+	case TruncateMiddle:
+		marker, markerWidth := w.truncationMarker(maxWidth)
+		if maxWidth <= markerWidth {
+			return []string{w.sliceVisible(field.text, 0, maxWidth)}
+		}
+		head := (maxWidth - markerWidth + 1) / 2
+		tail := maxWidth - markerWidth - head
+		return []string{w.sliceVisible(field.text, 0, head) + marker + w.sliceVisible(field.text, width-tail, width)}
+	default:
+		return []string{w.cutField(field.text, maxWidth)}
+	}
+}
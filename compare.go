@@ -0,0 +1,230 @@
+package TableWriter
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Markers drawn in the gutter between the tables rendered by [Writer.RenderComparison], as done by sdiff
+const (
+	gutterChanged   = "|"
+	gutterLeftOnly  = "<"
+	gutterRightOnly = ">"
+)
+
+// comparedRow is a pair of corresponding rows of the models compared by [Writer.RenderComparison].
+// Rows without a counterpart are paired with an empty one
+type comparedRow struct {
+	left   []string
+	right  []string
+	marker string
+}
+
+// RenderComparison writes the two models side by side, with a gutter of change markers between their corresponding
+// rows, which are matched by their key (see [Writer.SetRowKey]): "|" marks the rows whose values differ, while "<"
+// and ">" mark the rows found only in the left or in the right model. Unmatched rows are paired with blank ones.
+// Both tables are rendered with the configuration of the Writer and share the terminal's width. Wrapped fields are
+// cut instead, so that the corresponding rows stay aligned. The buffered data is not affected
+func (w *Writer) RenderComparison(left, right *Model) error {
+	w.refreshTerminalSize()
+	pairs := w.pairRows(left, right)
+	// Corresponding rows, starting from the headers, must span the same number of lines
+	heights := []int{max(rowHeight(left.Header), rowHeight(right.Header))}
+	leftModel := &Model{Header: equalizeHeight(left.Header, heights[0]), Rows: make([][]string, 0, len(pairs))}
+	rightModel := &Model{Header: equalizeHeight(right.Header, heights[0]), Rows: make([][]string, 0, len(pairs))}
+	for _, pair := range pairs {
+		if pair.left == nil {
+			pair.left = blankRow(len(left.Header))
+		}
+		if pair.right == nil {
+			pair.right = blankRow(len(right.Header))
+		}
+		height := max(rowHeight(pair.left), rowHeight(pair.right))
+		heights = append(heights, height)
+		leftModel.Rows = append(leftModel.Rows, equalizeHeight(pair.left, height))
+		rightModel.Rows = append(rightModel.Rows, equalizeHeight(pair.right, height))
+	}
+
+	sideWidth := 0
+	if w.termCols > 0 {
+		sideWidth = max((w.termCols-len(" | "))/2, 1)
+	}
+	leftLines, err := w.comparisonSide(leftModel, sideWidth)
+	if err != nil {
+		return err
+	}
+	rightLines, err := w.comparisonSide(rightModel, sideWidth)
+	if err != nil {
+		return err
+	}
+
+	// Each row starts after the top border, or after the separator below the previous row
+	markers := make(map[int]string, len(pairs))
+	line := 1 + heights[0] + 1
+	for r, pair := range pairs {
+		markers[line] = pair.marker
+		line += heights[r+1] + 1
+	}
+	leftWidth := 0
+	for _, l := range leftLines {
+		leftWidth = max(leftWidth, w.stringWidth(stripEscapeCodes(l)))
+	}
+	var buf bytes.Buffer
+	for l := range max(len(leftLines), len(rightLines)) {
+		leftLine, rightLine := "", ""
+		if l < len(leftLines) {
+			leftLine = leftLines[l]
+		}
+		if l < len(rightLines) {
+			rightLine = rightLines[l]
+		}
+		leftLine += strings.Repeat(" ", leftWidth-w.stringWidth(stripEscapeCodes(leftLine)))
+		buf.WriteString(strings.TrimRight(leftLine+" "+w.gutterMarker(markers[l])+" "+rightLine, " ") + "\n")
+	}
+	return w.write(buf.Bytes())
+}
+
+// pairRows pairs the data rows of the given models by their key, preserving the order of the left model.
+// The rows found only in the right model follow, in their order
+func (w *Writer) pairRows(left, right *Model) []comparedRow {
+	key := func(row []string) (string, bool) {
+		if w.keyCol >= len(row) {
+			return "", false
+		}
+		k := stripEscapeCodes(row[w.keyCol])
+		return k, k != ""
+	}
+	matches := make(map[string]int, len(right.Rows))
+	for r, row := range right.Rows {
+		if k, ok := key(row); ok {
+			if _, found := matches[k]; !found {
+				matches[k] = r
+			}
+		}
+	}
+
+	paired := make([]bool, len(right.Rows))
+	pairs := make([]comparedRow, 0, len(left.Rows)+len(right.Rows))
+	for _, row := range left.Rows {
+		k, ok := key(row)
+		r, found := matches[k]
+		if !ok || !found || paired[r] {
+			pairs = append(pairs, comparedRow{left: row, marker: gutterLeftOnly})
+			continue
+		}
+		paired[r] = true
+		pair := comparedRow{left: row, right: right.Rows[r]}
+		for c := range max(len(row), len(right.Rows[r])) {
+			if stripEscapeCodes(fieldAt(row, c)) != stripEscapeCodes(fieldAt(right.Rows[r], c)) {
+				pair.marker = gutterChanged
+				break
+			}
+		}
+		pairs = append(pairs, pair)
+	}
+	for r, row := range right.Rows {
+		if !paired[r] {
+			pairs = append(pairs, comparedRow{right: row, marker: gutterRightOnly})
+		}
+	}
+	return pairs
+}
+
+// fieldAt returns the field of the given row at the given column, or an empty string if the row is too short
+func fieldAt(row []string, c int) string {
+	if c < len(row) {
+		return row[c]
+	}
+	return ""
+}
+
+// blankRow returns an empty row with the given number of columns, which still renders as a row rather than being
+// skipped as an empty line
+func blankRow(columns int) []string {
+	row := make([]string, max(columns, 1))
+	if len(row) == 1 {
+		row[0] = " "
+	}
+	return row
+}
+
+// rowHeight returns the number of lines spanned by the given row, according to the line breaks of its fields.
+// As in [Writer.truncateField], a trailing line break does not start a new line
+func rowHeight(row []string) int {
+	height := 1
+	for _, field := range row {
+		lines := 0
+		for range strings.Lines(field) {
+			lines++
+		}
+		height = max(height, lines)
+	}
+	return height
+}
+
+// equalizeHeight returns a copy of the given row spanning the given number of lines, by appending line breaks to its
+// first field
+func equalizeHeight(row []string, height int) []string {
+	equalized := append(make([]string, 0, max(len(row), 1)), row...)
+	if len(equalized) == 0 {
+		equalized = append(equalized, "")
+	}
+	for rowHeight(equalized) < height {
+		equalized[0] += "\n"
+	}
+	return equalized
+}
+
+// comparisonSide renders one of the tables of a comparison, returning its lines. The table is rendered by a clone of
+// the Writer, whose features that could change the height of the rows or add lines between them are disabled
+func (w *Writer) comparisonSide(m *Model, width int) ([]string, error) {
+	var buf bytes.Buffer
+	side := w.Clone()
+	side.Clear()
+	side.output = &buf
+	side.flags &^= FollowMode | AlternateScreen | PreserveLongFields | ShowLegend
+	side.format = FormatTable
+	side.width = width
+	side.measureTerminal()
+	side.tableAlign = Left
+	side.guideEvery = 0
+	side.maxRowLines = 0
+	side.summaryLine = ""
+	side.statsHook = nil
+	side.eventHandler = nil
+	if side.defaultSpec.Truncate == TruncateWrap {
+		side.defaultSpec.Truncate = TruncateCut
+	}
+	for c, spec := range side.columnSpecs {
+		if spec.Truncate == TruncateWrap {
+			spec.Truncate = TruncateCut
+			side.columnSpecs[c] = spec
+		}
+	}
+	if _, err := m.WriteTo(side); err != nil {
+		return nil, err
+	}
+	if err := side.render(); err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"), nil
+}
+
+// gutterMarker returns the given gutter marker, colored unless [StripColours] is set, or a blank for unchanged rows
+func (w *Writer) gutterMarker(marker string) string {
+	color := ""
+	switch marker {
+	case "":
+		return " "
+	case gutterChanged:
+		color = colorYellow
+	case gutterLeftOnly:
+		color = colorRed
+	case gutterRightOnly:
+		color = colorGreen
+	}
+	if w.flags&StripColours != 0 {
+		return marker
+	}
+	return color + marker + colorReset
+}
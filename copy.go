@@ -0,0 +1,68 @@
+package TableWriter
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// CopyMode defines how the values of a column are made available for copying, without the need to select them
+// across the table's borders or to recover their truncated parts
+type CopyMode uint
+
+const (
+	// CopyNone leaves the column's values untouched
+	CopyNone CopyMode = iota
+	// CopyList prefixes each value with a number and lists the complete values below the table
+	CopyList
+	// CopyOSC52 emits each value inside an OSC 52 sequence, asking the terminal to store it into the clipboard.
+	// Terminals process the sequences in order, so the clipboard ends up holding the last rendered value: this mode is
+	// mainly intended for tables displaying a single result
+	CopyOSC52
+)
+
+// osc52Sequence returns the OSC 52 sequence that asks the terminal to copy the given text into the clipboard
+func osc52Sequence(text string) string {
+	return "\033]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+}
+
+// copyValues returns the values of the data rows' fields belonging to the columns using a [CopyMode], captured before
+// they are transformed for display (e.g. by ditto marks, folded prefixes or annotations). Other fields are empty
+func (w *Writer) copyValues() [][]string {
+	values := make([][]string, len(w.rows))
+	for r := 1; r < len(w.rows); r++ {
+		values[r] = make([]string, len(w.rows[r]))
+		for c := range w.rows[r] {
+			if w.columnSpec(c).Copy != CopyNone {
+				values[r][c] = w.rows[r][c].plain
+			}
+		}
+	}
+	return values
+}
+
+// markCopyValues prepares the data rows' fields belonging to the columns using a [CopyMode], and returns the numbered
+// copy list to be rendered below the table. The copied values are the ones captured by [Writer.copyValues]
+func (w *Writer) markCopyValues(values [][]string) []byte {
+	list := make([]byte, 0)
+	n := 0
+	for r := 1; r < len(w.rows); r++ {
+		for c := range w.rows[r] {
+			field, value := &w.rows[r][c], values[r][c]
+			switch w.columnSpec(c).Copy {
+			case CopyList:
+				if value == "" {
+					continue
+				}
+				n++
+				marker := fmt.Sprintf("#%d", n)
+				list = append(list, marker+" "+value+"\n"...)
+				// The marker precedes the value, so that it survives truncation
+				field.text = marker + " " + field.text
+				field.plain = marker + " " + field.plain
+			case CopyOSC52:
+				field.prefix = osc52Sequence(value)
+			}
+		}
+	}
+	return list
+}
@@ -0,0 +1,35 @@
+package TableWriter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCopyListKeepsOriginalValues(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	tests := []struct {
+		name string
+		spec ColumnSpec
+		want string
+	}{
+		{name: "ditto marks", spec: ColumnSpec{Copy: CopyList, Ditto: DittoMark}, want: "#1 a/b\n#2 a/b\n#3 a/c\n"},
+		{name: "folded prefixes", spec: ColumnSpec{Copy: CopyList, FoldOn: '/'}, want: "#1 a/b\n#2 a/b\n#3 a/c\n"},
+	}
+	input := "Path\na/b\na/b\na/c\n"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderTable(t, input, StripColours, WithWidth(40), WithColumnSpec(0, tt.spec))
+			if !strings.HasSuffix(got, tt.want) {
+				t.Errorf("got:\n%s\nwant the copy list:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("annotations", func(t *testing.T) {
+		spec := ColumnSpec{Copy: CopyList, Annotate: AnnotateRank}
+		got := renderTable(t, "Score\n3\n1\n", StripColours, WithWidth(40), WithColumnSpec(0, spec))
+		if want := "#1 3\n#2 1\n"; !strings.HasSuffix(got, want) {
+			t.Errorf("got:\n%s\nwant the copy list:\n%s", got, want)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package TableWriter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Arrows appended to the fields of the columns tracking their trend
+const (
+	trendUp        = "▲"
+	trendDown      = "▼"
+	trendSteady    = "="
+	asciiTrendUp   = "^"
+	asciiTrendDown = "v"
+)
+
+// SetRowKey selects the column identifying each data row across flushes, which is used to compare the rows with the
+// ones of the previous flush. Rows without a key field are never compared. The first column is used by default
+func (w *Writer) SetRowKey(col int) {
+	w.keyCol = max(col, 0)
+}
+
+// WithRowKey selects the column identifying each data row across flushes. See [Writer.SetRowKey]
+func WithRowKey(col int) Option {
+	return func(w *Writer) {
+		w.SetRowKey(col)
+	}
+}
+
+// rowKey returns the key identifying the given data row, if any
+func (w *Writer) rowKey(cells []cell) (string, bool) {
+	if w.keyCol >= len(cells) || cells[w.keyCol].plain == "" {
+		return "", false
+	}
+	return cells[w.keyCol].plain, true
+}
+
+// compareRows compares the data rows with the ones of the same key rendered by the previous flush, then records the
+// current values for the next one. Changed fields are colored when [HighlightChanges] is set, while the fields of the
+// columns tracking their trend are followed by an arrow. Rows that were not rendered by the previous flush are left
+// untouched
+func (w *Writer) compareRows() {
+	current := make(map[string][]string)
+	for _, cells := range w.rows[min(1, len(w.rows)):] {
+		key, ok := w.rowKey(cells)
+		if !ok {
+			continue
+		}
+		values := make([]string, len(cells))
+		for c := range cells {
+			values[c] = cells[c].plain
+		}
+		current[key] = values
+
+		previous, found := w.previous[key]
+		if !found {
+			continue
+		}
+		for c := range cells {
+			if w.flags&HighlightChanges != 0 && w.flags&StripColours == 0 &&
+				(c >= len(previous) || cells[c].plain != previous[c]) {
+				cells[c].text = colorYellow + cells[c].text + colorReset
+				w.useLegendEntry(legendChanged)
+			}
+			if w.columnSpec(c).Trend && c < len(previous) {
+				w.appendTrend(&cells[c], previous[c])
+			}
+		}
+	}
+	w.previous = current
+}
+
+// parseNumber parses the numeric value of a field, ignoring the surrounding spaces and a trailing percent sign
+func parseNumber(s string) (float64, bool) {
+	n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	return n, err == nil
+}
+
+// appendTrend appends to the given field an arrow comparing its numeric value with the previous one.
+// Non-numeric fields are left untouched
+func (w *Writer) appendTrend(field *cell, previous string) {
+	value, ok := parseNumber(field.plain)
+	last, lastOk := parseNumber(previous)
+	if !ok || !lastOk {
+		return
+	}
+	arrow, color, entry := trendSteady, "", legendEntry(0)
+	switch {
+	case value > last:
+		arrow, color, entry = trendUp, colorGreen, legendIncreased
+		if w.flags&AsciiTable != 0 {
+			arrow = asciiTrendUp
+		}
+	case value < last:
+		arrow, color, entry = trendDown, colorRed, legendDecreased
+		if w.flags&AsciiTable != 0 {
+			arrow = asciiTrendDown
+		}
+	}
+	arrow = w.glyph(arrow)
+	field.plain += " " + arrow
+	if w.flags&StripColours != 0 || color == "" {
+		field.text += " " + arrow
+	} else {
+		field.text += " " + color + arrow + colorReset
+		w.useLegendEntry(entry)
+	}
+}
@@ -0,0 +1,35 @@
+package TableWriter
+
+// dittoMark replaces the fields identical to the ones directly above them, when [DittoMark] is used
+const dittoMark = `"`
+
+// DittoMode defines how the data fields identical to the ones directly above them are displayed, in order to
+// reduce the visual noise of sorted tables
+type DittoMode uint
+
+const (
+	// DittoNone displays all the fields
+	DittoNone DittoMode = iota
+	// DittoMark replaces the repeated fields with a ditto mark (")
+	DittoMark
+	// DittoBlank leaves the repeated fields empty
+	DittoBlank
+)
+
+// suppressDittos replaces the data fields identical to the ones directly above them in the columns using a
+// [DittoMode]. Empty fields are never replaced
+func (w *Writer) suppressDittos() {
+	for r := len(w.rows) - 1; r > 1; r-- {
+		for c := range w.rows[r] {
+			mode := w.columnSpec(c).Ditto
+			above := w.rows[r-1]
+			if mode == DittoNone || c >= len(above) || w.rows[r][c].plain == "" || w.rows[r][c].plain != above[c].plain {
+				continue
+			}
+			w.rows[r][c] = cell{}
+			if mode == DittoMark {
+				w.rows[r][c] = cell{text: dittoMark, plain: dittoMark}
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package TableWriter
+
+import (
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ExportEncoding defines the character encoding of the formats exported by the [Writer], so that files consumed by
+// spreadsheets or legacy tools open correctly. Drawn tables are always encoded in UTF-8
+type ExportEncoding uint
+
+const (
+	// EncodingUTF8 encodes the exports in UTF-8, without byte order mark
+	EncodingUTF8 ExportEncoding = iota
+	// EncodingUTF8BOM encodes the exports in UTF-8, preceded by a byte order mark, so that Excel detects it
+	EncodingUTF8BOM
+	// EncodingUTF16LE encodes the exports in little-endian UTF-16, preceded by a byte order mark
+	EncodingUTF16LE
+	// EncodingLatin1 encodes the exports in ISO-8859-1. Characters it cannot represent are replaced with '?'
+	EncodingLatin1
+	// EncodingWindows1252 encodes the exports in Windows-1252. Characters it cannot represent are replaced with '?'
+	EncodingWindows1252
+)
+
+// windows1252 maps the characters that Windows-1252 encodes in place of the C1 control characters of ISO-8859-1
+var windows1252 = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a,
+	'‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// SetExportEncoding defines the character encoding of the formats selected with [Writer.SetOutputFormat], e.g. to
+// prepend a byte order mark to the CSV files opened by Excel. Drawn tables are always encoded in UTF-8
+func (w *Writer) SetExportEncoding(encoding ExportEncoding) {
+	w.encoding = encoding
+}
+
+// WithExportEncoding defines the character encoding of the exported formats. See [Writer.SetExportEncoding]
+func WithExportEncoding(encoding ExportEncoding) Option {
+	return func(w *Writer) {
+		w.encoding = encoding
+	}
+}
+
+// encode converts the given UTF-8 export into the selected [ExportEncoding]
+func (w *Writer) encode(export []byte) []byte {
+	switch w.encoding {
+	case EncodingUTF8BOM:
+		return append([]byte("\ufeff"), export...)
+	case EncodingUTF16LE:
+		encoded := make([]byte, 0, 2*len(export)+2)
+		for _, unit := range utf16.Encode(append([]rune{'\ufeff'}, []rune(string(export))...)) {
+			encoded = append(encoded, byte(unit), byte(unit>>8))
+		}
+		return encoded
+	case EncodingLatin1, EncodingWindows1252:
+		encoded := make([]byte, 0, utf8.RuneCount(export))
+		for _, r := range string(export) {
+			b, ok := windows1252[r]
+			switch {
+			case ok && w.encoding == EncodingWindows1252:
+			case r < 0x80, r >= 0xa0 && r <= 0xff, r <= 0xff && w.encoding == EncodingLatin1:
+				b = byte(r)
+			default:
+				b = '?'
+			}
+			encoded = append(encoded, b)
+		}
+		return encoded
+	default:
+		return export
+	}
+}
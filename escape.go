@@ -0,0 +1,28 @@
+package TableWriter
+
+import "strings"
+
+// Private APC strings standing for the tabs and line breaks escaped by [EscapeCell].
+// Being escape sequences, they go through the sanitizing untouched, while raw control characters written to the
+// [Writer] (e.g. unit and record separators) are sanitized like any other invisible character
+const (
+	escapedTab     = "\x1b_TableWriter;tab\x1b\\"
+	escapedNewline = "\x1b_TableWriter;newline\x1b\\"
+)
+
+var (
+	cellEscaper   = strings.NewReplacer("\r\n", escapedNewline, "\t", escapedTab, "\n", escapedNewline)
+	cellUnescaper = strings.NewReplacer(escapedTab, "\t", escapedNewline, "\n")
+)
+
+// EscapeCell escapes the tabs and line breaks contained in the given value, so that it can be written to a [Writer]
+// as a single field. Escaped values are restored before the layout: line breaks split the field over multiple lines,
+// while tabs are displayed as spaces. [Model.WriteTo] escapes the fields automatically
+func EscapeCell(s string) string {
+	return cellEscaper.Replace(s)
+}
+
+// unescapeCell restores the tabs and line breaks escaped by [EscapeCell]
+func unescapeCell(s string) string {
+	return cellUnescaper.Replace(s)
+}
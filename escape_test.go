@@ -0,0 +1,39 @@
+package TableWriter
+
+import "testing"
+
+func TestEscapeCell(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "raw separators are sanitized",
+			input: "Name\tAge\nAl\x1eice\t3\x1f0\n",
+			want: "┌──────┬────┐\n" +
+				"│Name  │Age │\n" +
+				"├──────┼────┤\n" +
+				"│Alice │30  │\n" +
+				"└──────┴────┘\n",
+		},
+		{
+			name:  "escaped tabs and line breaks are restored",
+			input: "Name\tNote\nBob\t" + EscapeCell("a\tb\nc") + "\n",
+			want: "┌─────┬─────┐\n" +
+				"│Name │Note │\n" +
+				"├─────┼─────┤\n" +
+				"│Bob  │a b  │\n" +
+				"│     │c    │\n" +
+				"└─────┴─────┘\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderTable(t, tt.input, StripColours, WithWidth(40)); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
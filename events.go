@@ -0,0 +1,56 @@
+package TableWriter
+
+import "time"
+
+// Event is emitted by the [Writer] during the render lifecycle. It is one of [RenderStarted], [RowTruncated],
+// [ColumnDropped] or [RenderFinished]
+type Event interface {
+	event()
+}
+
+// RenderStarted is emitted when a flush starts rendering the buffered data
+type RenderStarted struct{}
+
+// RowTruncated is emitted for each field that does not fit its column, and has been truncated or wrapped.
+// Row 0 is the header
+type RowTruncated struct {
+	Row int
+	Col int
+}
+
+// ColumnDropped is emitted for each column removed from the table because it does not fit the terminal
+type ColumnDropped struct {
+	Col int
+}
+
+// RenderFinished is emitted when a render has been written to the output
+type RenderFinished struct {
+	Bytes    int
+	Duration time.Duration
+}
+
+func (RenderStarted) event()  {}
+func (RowTruncated) event()   {}
+func (ColumnDropped) event()  {}
+func (RenderFinished) event() {}
+
+// SetEventHandler registers a callback invoked with the events of the render lifecycle, so that applications can
+// react to them (e.g. warning the user about truncated fields) without parsing the output
+func (w *Writer) SetEventHandler(handler func(Event)) {
+	w.eventHandler = handler
+}
+
+// WithEventHandler registers a callback invoked with the events of the render lifecycle.
+// See [Writer.SetEventHandler]
+func WithEventHandler(handler func(Event)) Option {
+	return func(w *Writer) {
+		w.SetEventHandler(handler)
+	}
+}
+
+// emit sends the given event to the registered handler, if any
+func (w *Writer) emit(event Event) {
+	if w.eventHandler != nil {
+		w.eventHandler(event)
+	}
+}
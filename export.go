@@ -0,0 +1,209 @@
+package TableWriter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// OutputFormat defines how the data received by the [Writer] is rendered at each flush
+type OutputFormat uint
+
+const (
+	// FormatTable draws the data as a table, styled according to the flags
+	FormatTable OutputFormat = iota
+	// FormatHTML emits the data as an HTML table, translating the ANSI styles into inline CSS
+	FormatHTML
+	// FormatCSV emits the colorless fields as RFC 4180 CSV, quoting them as needed
+	FormatCSV
+	// FormatTSV emits the colorless fields as tab-separated lines, escaping their tabs and line breaks as
+	// [EscapeCell] does, so that the output can be written back to a [Writer]
+	FormatTSV
+	// FormatJSON emits the colorless data rows as a JSON array of objects, whose keys are the header's fields
+	FormatJSON
+	// FormatJSONArrays emits the colorless rows, including the header, as a JSON array of arrays
+	FormatJSONArrays
+	// FormatRST emits the colorless fields as a reStructuredText grid table, whose first row is the header
+	FormatRST
+	// FormatAsciiDoc emits the colorless fields as an AsciiDoc table, whose first row is the header
+	FormatAsciiDoc
+	// FormatJira emits the colorless fields as a Jira/Confluence wiki markup table, whose first row is the header
+	FormatJira
+	// FormatJSONMetadata emits a JSON object holding the [TableMetadata] of the exported rows, under "metadata", and
+	// the data rows as [FormatJSON] does, under "rows"
+	FormatJSONMetadata
+)
+
+// SetOutputFormat defines how the data is rendered at each flush. Formats other than [FormatTable] export the parsed
+// fields, so that the same data can be shown in web pages or fed to other tools, while the table's layout and the
+// terminal-specific features (e.g. [FollowMode] and [AlternateScreen]) are ignored
+func (w *Writer) SetOutputFormat(format OutputFormat) {
+	w.format = format
+}
+
+// WithOutputFormat defines how the data is rendered at each flush. See [Writer.SetOutputFormat]
+func WithOutputFormat(format OutputFormat) Option {
+	return func(w *Writer) {
+		w.format = format
+	}
+}
+
+// export renders the parsed rows in the selected [OutputFormat]
+func (w *Writer) export() []byte {
+	switch w.format {
+	case FormatHTML:
+		return w.renderHTML()
+	case FormatCSV:
+		return w.renderCSV()
+	case FormatTSV:
+		return w.renderTSV()
+	case FormatJSON, FormatJSONArrays, FormatJSONMetadata:
+		return w.renderJSON()
+	case FormatRST:
+		return w.renderRST()
+	case FormatAsciiDoc:
+		return w.renderAsciiDoc()
+	case FormatJira:
+		return w.renderJira()
+	default:
+		return nil
+	}
+}
+
+// exportModel parses the buffered data into the [Model] written by the exports, whose header is renamed and whose
+// fields are anonymized as the rendered ones. See [Writer.RenameColumn] and [ColumnSpec.Anonymize]
+func (w *Writer) exportModel() *Model {
+	m := w.Model()
+	for c := range m.Header {
+		m.Header[c] = w.renamedHeader(m.Header[c], stripEscapeCodes(m.Header[c]))
+	}
+	for _, row := range m.Rows {
+		for c := range row {
+			if anonymous, ok := w.anonymize(c, stripEscapeCodes(row[c])); ok {
+				row[c] = anonymous
+			}
+		}
+	}
+	return m
+}
+
+// plainRows returns the colorless fields of the parsed rows
+func (w *Writer) plainRows() [][]string {
+	rows := make([][]string, len(w.rows))
+	for r, cells := range w.rows {
+		rows[r] = make([]string, len(cells))
+		for c := range cells {
+			rows[r][c] = cells[c].plain
+		}
+	}
+	return rows
+}
+
+// renderCSV renders the parsed rows as RFC 4180 CSV, with CRLF line endings
+func (w *Writer) renderCSV() []byte {
+	var buf bytes.Buffer
+	out := csv.NewWriter(&buf)
+	out.UseCRLF = true
+	// Writing to memory cannot fail
+	_ = out.WriteAll(w.plainRows())
+	return buf.Bytes()
+}
+
+// renderTSV renders the parsed rows as tab-separated lines
+func (w *Writer) renderTSV() []byte {
+	var sb strings.Builder
+	for _, row := range w.plainRows() {
+		for c, field := range row {
+			if c > 0 {
+				sb.WriteByte('\t')
+			}
+			sb.WriteString(EscapeCell(field))
+		}
+		sb.WriteByte('\n')
+	}
+	return []byte(sb.String())
+}
+
+// renderJSON renders the parsed rows as a JSON array holding one row per line. With [FormatJSON], each data row is an
+// object whose keys are the header's fields: rows lacking some fields hold empty strings, while the exceeding fields
+// are keyed by their column's index. Keys are made unique as the columns exported to databases (see [columnNames]).
+// With [FormatJSONMetadata], the array is wrapped in an object along with the rows' metadata
+func (w *Writer) renderJSON() []byte {
+	rows := w.plainRows()
+	var header []string
+	if w.format != FormatJSONArrays && len(rows) > 0 {
+		header, rows = rows[0], rows[1:]
+	}
+	if w.format != FormatJSONMetadata {
+		return append(jsonRows(jsonKeys(header, rows), rows, w.format == FormatJSONArrays, ""), '\n')
+	}
+
+	m := &Model{Header: header, Rows: rows}
+	// Metadata that cannot be encoded, like sums overflowing a float64, is left null
+	metadata, err := json.Marshal(TableMetadata{Rows: len(rows), Columns: w.inferSchema(m)})
+	if err != nil {
+		metadata = []byte("null")
+	}
+	var buf bytes.Buffer
+	buf.WriteString("{\n  \"metadata\": ")
+	buf.Write(metadata)
+	buf.WriteString(",\n  \"rows\": ")
+	buf.Write(jsonRows(jsonKeys(header, rows), rows, false, "  "))
+	buf.WriteString("\n}\n")
+	return buf.Bytes()
+}
+
+// jsonKeys returns the unique keys of the objects encoding the given data rows: the header's fields, followed by the
+// indexes of the columns exceeding it
+func jsonKeys(header []string, rows [][]string) []string {
+	width := len(header)
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	schema := make([]ColumnSchema, width)
+	for c := range schema {
+		schema[c].Name = strconv.Itoa(c)
+		if c < len(header) {
+			schema[c].Name = header[c]
+		}
+	}
+	return columnNames(schema)
+}
+
+// jsonRows encodes the given rows as a JSON array holding one row per line, indented by the given prefix. Rows are
+// encoded as arrays, or as objects with the given keys
+func jsonRows(keys []string, rows [][]string, arrays bool, indent string) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for r, row := range rows {
+		if r > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString("\n  " + indent)
+		if arrays {
+			// Marshaling strings cannot fail
+			encoded, _ := json.Marshal(row)
+			buf.Write(encoded)
+			continue
+		}
+		buf.WriteByte('{')
+		for c, key := range keys {
+			if c > 0 {
+				buf.WriteByte(',')
+			}
+			encodedKey, _ := json.Marshal(key)
+			encodedValue, _ := json.Marshal(fieldAt(row, c))
+			buf.Write(encodedKey)
+			buf.WriteByte(':')
+			buf.Write(encodedValue)
+		}
+		buf.WriteByte('}')
+	}
+	if len(rows) > 0 {
+		buf.WriteString("\n" + indent)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes()
+}
@@ -0,0 +1,100 @@
+package TableWriter
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestExportsRenameColumns(t *testing.T) {
+	const input = "user\tsize\nalice\t10\n"
+	opts := []Option{WithMetadata("Unit", "MB")}
+	setup := func(w *Writer) {
+		w.RenameColumn("user", "User")
+		w.RenameColumn("size", "Size ({{.Unit}})")
+	}
+
+	var sb strings.Builder
+	w := NewWriter(&sb, 0, append(opts, WithOutputFormat(FormatCSV))...)
+	setup(w)
+	_, _ = io.WriteString(w, input)
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if want := "User,Size (MB)\r\nalice,10\r\n"; sb.String() != want {
+		t.Errorf("CSV output = %q, want %q", sb.String(), want)
+	}
+
+	w = NewWriter(io.Discard, 0, opts...)
+	setup(w)
+	_, _ = io.WriteString(w, input)
+	sheet := xlsxSheet(t, w)
+	for _, want := range []string{">User</t>", ">Size (MB)</t>"} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet does not contain %s:\n%s", want, sheet)
+		}
+	}
+}
+
+func TestExportsAnonymizeColumns(t *testing.T) {
+	const input = "user\tsize\nalice\t10\nbob\t20\nalice\t30\n"
+	spec := WithColumnSpec(0, ColumnSpec{Anonymize: AnonymizePseudonym, Pseudonym: "user"})
+
+	var sb strings.Builder
+	w := NewWriter(&sb, 0, spec, WithOutputFormat(FormatTSV))
+	_, _ = io.WriteString(w, input)
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if want := "user\tsize\nuser-1\t10\nuser-2\t20\nuser-1\t30\n"; sb.String() != want {
+		t.Errorf("TSV output = %q, want %q", sb.String(), want)
+	}
+
+	w = NewWriter(io.Discard, 0, spec)
+	_, _ = io.WriteString(w, input)
+	sheet := xlsxSheet(t, w)
+	if strings.Contains(sheet, "alice") || strings.Contains(sheet, "bob") {
+		t.Errorf("sheet leaks the anonymized values:\n%s", sheet)
+	}
+	for _, want := range []string{">user-1</t>", ">user-2</t>"} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet does not contain %s:\n%s", want, sheet)
+		}
+	}
+}
+
+func TestExportJSONMetadata(t *testing.T) {
+	var sb strings.Builder
+	w := NewWriter(&sb, 0, WithOutputFormat(FormatJSONMetadata))
+	_, _ = io.WriteString(w, "name\tscore\nalice\t1.5\nbob\tNaN\ncarol\t-Inf\n")
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	want := `{
+  "metadata": {"rows":3,"columns":[` +
+		`{"name":"name","type":"string","width":5,"nulls":0,"unique":3},` +
+		`{"name":"score","type":"string","width":4,"nulls":0,"unique":3}]},
+  "rows": [
+    {"name":"alice","score":"1.5"},
+    {"name":"bob","score":"NaN"},
+    {"name":"carol","score":"-Inf"}
+  ]
+}
+`
+	if got := sb.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestExportJSONUniqueKeys(t *testing.T) {
+	var sb strings.Builder
+	w := NewWriter(&sb, 0, WithOutputFormat(FormatJSON))
+	_, _ = io.WriteString(w, "id\tName\tname\t\t5\na\tb\tc\td\te\tf\n")
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	want := "[\n  " + `{"id":"a","Name":"b","name_2":"c","column4":"d","5":"e","5_2":"f"}` + "\n]\n"
+	if got := sb.String(); got != want {
+		t.Errorf("JSON output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,43 @@
+package TableWriter
+
+import "strings"
+
+// foldPrefixes hides the leading segments shared by each data field with the one directly above it, in the columns
+// folded with [ColumnSpec.FoldOn]. The hidden segments are replaced by spaces, so that the remaining suffix keeps its
+// position, as in the output of the tree command. The last segment of each field is always displayed
+func (w *Writer) foldPrefixes() {
+	for r := len(w.rows) - 1; r > 1; r-- {
+		for c := range w.rows[r] {
+			separator := w.columnSpec(c).FoldOn
+			above := w.rows[r-1]
+			if separator == 0 || c >= len(above) {
+				continue
+			}
+			field := &w.rows[r][c]
+			prefix := commonPrefix(field.plain, above[c].plain, separator)
+			if prefix == "" {
+				continue
+			}
+			width := w.stringWidth(prefix)
+			indent := strings.Repeat(" ", width)
+			field.text = indent + w.sliceVisible(field.text, width, w.stringWidth(field.plain))
+			field.plain = indent + field.plain[len(prefix):]
+		}
+	}
+}
+
+// commonPrefix returns the leading segments, separator included, that the value shares with the other one.
+// The last segment of the value is never part of the prefix
+func commonPrefix(value, other string, separator rune) string {
+	end := 0
+	for i, r := range value {
+		if r != separator {
+			continue
+		}
+		if !strings.HasPrefix(other, value[:i+len(string(separator))]) {
+			break
+		}
+		end = i + len(string(separator))
+	}
+	return value[:end]
+}
@@ -0,0 +1,90 @@
+package TableWriter
+
+import (
+	"bytes"
+	"slices"
+)
+
+// followState keeps track of the table rendered across multiple flushes when [FollowMode] is set
+type followState struct {
+	header    []cell   // Header row, as rendered by the first flush
+	columns   []column // Columns' widths, locked by the first flush
+	last      []cell   // Last rendered row, used to close the table
+	separator []byte   // Separator line following the last rendered row, written before the next one
+	rows      int      // Number of data rows rendered so far
+	lines     int      // Number of physical lines rendered since the last header
+}
+
+// isFollowing reports whether the current flush is appending rows to a table rendered by a previous one
+func (w *Writer) isFollowing() bool {
+	return w.flags&FollowMode != 0 && w.follow.header != nil
+}
+
+// lockColumns restores the columns' widths chosen by the first flush, so that appended rows stay aligned with the
+// previous ones. Fields that do not fit anymore are processed according to their column's [TruncatePolicy]
+func (w *Writer) lockColumns() {
+	if !w.isFollowing() {
+		return
+	}
+	for c := range min(len(w.columns), len(w.follow.columns)) {
+		w.columns[c] = w.follow.columns[c]
+	}
+	w.debug("columns' widths locked by follow mode", "widths", w.columnWidths())
+}
+
+// followRow renders a row of a table in [FollowMode]. Since the last row could be followed by the next flush's
+// ones, or by the table's bottom border, each row's separator is only written before the next row.
+// The header is also repeated before the row when the previous one would scroll out of the terminal, unless the
+// terminal's height is unknown
+func (w *Writer) followRow(l int, rowBuffer []byte, separator []byte) []byte {
+	followBuffer := w.follow.separator
+	w.follow.separator = separator
+	if l == 0 {
+		// The top border precedes the header
+		w.follow.lines = bytes.Count(rowBuffer, []byte{'\n'}) + 1
+		return rowBuffer
+	}
+
+	lines := bytes.Count(followBuffer, []byte{'\n'}) + bytes.Count(rowBuffer, []byte{'\n'})
+	if w.termRows > 0 && w.follow.lines+lines > w.termRows {
+		headerBlock := w.headerBlock()
+		w.follow.lines = bytes.Count(headerBlock, []byte{'\n'})
+		followBuffer = append(followBuffer, headerBlock...)
+	}
+	w.follow.lines += lines
+	return append(followBuffer, rowBuffer...)
+}
+
+// updateFollowState records the header, the columns and the last row rendered by the current flush
+func (w *Writer) updateFollowState() {
+	if w.flags&FollowMode == 0 || len(w.rows) == 0 {
+		return
+	}
+	if w.follow.header == nil {
+		w.follow.header = w.rows[0]
+	} else if len(w.rows) == 1 {
+		// Nothing has been appended to the table
+		return
+	}
+	w.follow.columns = slices.Clone(w.columns)
+	w.follow.last = w.rows[len(w.rows)-1]
+	w.follow.rows += len(w.rows) - 1
+}
+
+// EndTable closes the table rendered in [FollowMode] by writing its bottom border, so that the next flush starts a
+// new table with its own header. Buffered data is flushed first, regardless of any rate limit
+func (w *Writer) EndTable() error {
+	if err := w.render(); err != nil {
+		return err
+	}
+	if w.follow.header == nil {
+		return nil
+	}
+	last := w.follow.last
+	columns := w.columns
+	w.columns = w.follow.columns
+	bottom := w.rowHLine(&w.divider, last, w.visibleColumns(last), 1, true)
+	w.columns = columns
+	w.follow = followState{}
+	return w.write(bottom)
+}
@@ -0,0 +1,66 @@
+package TableWriter
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestFollowMode(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	var buf bytes.Buffer
+	w := NewWriter(&buf, StripColours|FollowMode, WithWidth(40), WithHeight(6))
+	steps := []struct {
+		name  string
+		input string
+		end   bool
+		want  string
+	}{
+		{
+			name:  "first flush",
+			input: "Name\tAge\nAlice\t30\n",
+			want: "┌──────┬────┐\n" +
+				"│Name  │Age │\n" +
+				"├──────┼────┤\n" +
+				"│Alice │30  │\n",
+		},
+		{
+			// Columns keep their widths, while the header is repeated before scrolling out of the terminal
+			name:  "appended rows",
+			input: "Bob\t4\nCarolina\t100\n",
+			want: "├──────┼────┤\n" +
+				"│Bob   │4   │\n" +
+				"├──────┼────┤\n" +
+				"│Name  │Age │\n" +
+				"├──────┼────┤\n" +
+				"│Caro… │100 │\n",
+		},
+		{name: "end of the table", end: true, want: "└──────┴────┘\n"},
+		{
+			name:  "new table",
+			input: "X\tY\n1\t2\n",
+			end:   true,
+			want: "┌──┬──┐\n" +
+				"│X │Y │\n" +
+				"├──┼──┤\n" +
+				"│1 │2 │\n" +
+				"└──┴──┘\n",
+		},
+	}
+	for _, step := range steps {
+		buf.Reset()
+		if _, err := io.WriteString(w, step.input); err != nil {
+			t.Fatalf("%s: Write() error = %v", step.name, err)
+		}
+		flush := w.Flush
+		if step.end {
+			flush = w.EndTable
+		}
+		if err := flush(); err != nil {
+			t.Fatalf("%s: flush error = %v", step.name, err)
+		}
+		if got := buf.String(); got != step.want {
+			t.Errorf("%s: got:\n%s\nwant:\n%s", step.name, got, step.want)
+		}
+	}
+}
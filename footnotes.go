@@ -0,0 +1,59 @@
+package TableWriter
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// superscriptDigits maps each decimal digit to its superscript version, used to render footnote markers
+var superscriptDigits = strings.NewReplacer(
+	"0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴",
+	"5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹",
+)
+
+// footnote is an annotation attached to a single field of the table
+type footnote struct {
+	row  int
+	col  int
+	text string
+}
+
+// AddFootnote attaches an annotation to the field at the given row and column, where row 0 is the header.
+// The field is marked with a superscript number, while the annotation is listed below the table.
+// Footnotes are numbered following the table's order and are discarded along with the buffered data
+func (w *Writer) AddFootnote(row, col int, text string) {
+	w.footnotes = append(w.footnotes, footnote{row: row, col: col, text: text})
+}
+
+// footnoteMarker returns the marker for the footnote with the given number
+func (w *Writer) footnoteMarker(n int) string {
+	if w.flags&AsciiTable != 0 {
+		return "[" + strconv.Itoa(n) + "]"
+	}
+	return superscriptDigits.Replace(strconv.Itoa(n))
+}
+
+// markFootnotes appends the footnote markers to the annotated fields and returns the list of footnotes to be rendered
+// below the table. Footnotes referring to missing fields are ignored
+func (w *Writer) markFootnotes() []byte {
+	footnotes := slices.Clone(w.footnotes)
+	slices.SortStableFunc(footnotes, func(a, b footnote) int {
+		return cmp.Or(cmp.Compare(a.row, b.row), cmp.Compare(a.col, b.col))
+	})
+
+	list := make([]byte, 0)
+	n := 0
+	for _, f := range footnotes {
+		if f.row < 0 || f.row >= len(w.rows) || f.col < 0 || f.col >= len(w.rows[f.row]) {
+			continue
+		}
+		n++
+		marker := w.footnoteMarker(n)
+		w.rows[f.row][f.col].text += marker
+		w.rows[f.row][f.col].plain += marker
+		list = append(list, marker+" "+f.text+"\n"...)
+	}
+	return list
+}
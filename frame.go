@@ -0,0 +1,86 @@
+package TableWriter
+
+import (
+	"strings"
+)
+
+// Frame defines how the outer border of the table is drawn, in order to emphasize important tables
+type Frame uint
+
+const (
+	// FrameDefault draws the outer border like the inner lines
+	FrameDefault Frame = iota
+	// FrameDouble draws the outer border with double lines
+	FrameDouble
+	// FrameShadow draws a drop shadow under the right and bottom sides of the table
+	FrameShadow
+)
+
+// Glyphs used to draw the shadow of tables using [FrameShadow]
+const (
+	shadowGlyph      = "▒"
+	asciiShadowGlyph = "#"
+)
+
+// SetFrame defines how the outer border of the table is drawn, distinguishing it from the inner structure.
+// Frames are ignored when [CopyFriendly] is set
+func (w *Writer) SetFrame(frame Frame) {
+	w.frame = frame
+	w.initDividers()
+}
+
+// WithFrame defines how the outer border of the table is drawn. See [Writer.SetFrame]
+func WithFrame(frame Frame) Option {
+	return func(w *Writer) {
+		w.frame = frame
+	}
+}
+
+// applyFrame replaces the dividers of the table's outer border according to the selected [Frame]
+func (w *Writer) applyFrame() {
+	if w.frame != FrameDouble || w.flags&CopyFriendly != 0 {
+		return
+	}
+	if w.flags&AsciiTable != 0 {
+		w.divider.OuterHLine = "="
+		return
+	}
+	w.divider.OuterHLine = "═"
+	w.divider.OuterVLine = "║"
+	w.divider.TL = "╔"
+	w.divider.TR = "╗"
+	w.divider.BL = "╚"
+	w.divider.BR = "╝"
+	w.divider.TUp = "╤"
+	w.divider.TDown = "╧"
+	w.divider.VLeft = "╟"
+	w.divider.VRight = "╢"
+}
+
+// shadowTable adds a drop shadow to the right and bottom sides of the rendered table when [FrameShadow] is selected.
+// Tables rendered in [FollowMode] are never closed, so they cannot have a shadow
+func (w *Writer) shadowTable(table []byte) []byte {
+	if w.frame != FrameShadow || w.flags&(CopyFriendly|FollowMode) != 0 || len(table) == 0 {
+		return table
+	}
+	shadow := shadowGlyph
+	if w.flags&AsciiTable != 0 {
+		shadow = asciiShadowGlyph
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(table), "\n"), "\n")
+	width := 0
+	for _, line := range lines {
+		width = max(width, w.stringWidth(stripEscapeCodes(line)))
+	}
+	var sb strings.Builder
+	for i, line := range lines {
+		sb.WriteString(line)
+		if i > 0 {
+			sb.WriteString(strings.Repeat(" ", width-w.stringWidth(stripEscapeCodes(line))) + w.colorBorder(shadow))
+		}
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(" " + w.colorBorder(strings.Repeat(shadow, width)) + "\n")
+	return []byte(sb.String())
+}
@@ -0,0 +1,89 @@
+package TableWriter
+
+import "slices"
+
+// Markers prefixed to the header of collapsed column groups
+const (
+	groupMarker      = "▸ "
+	asciiGroupMarker = "> "
+)
+
+// columnGroup is a set of columns that can be collapsed into a single one, in order to keep tables compact
+type columnGroup struct {
+	name     string
+	cols     []int
+	expanded bool
+}
+
+// SetColumnGroup defines a group of columns, identified by their indexes, that is rendered as a single column
+// headed "▸ name" until it is expanded with [Writer.ExpandColumnGroup]. Interactive hosts can expand and collapse the
+// group in response to a keypress, and flush the table again. Defining an existing group replaces it
+func (w *Writer) SetColumnGroup(name string, cols ...int) {
+	group := columnGroup{name: name, cols: slices.Sorted(slices.Values(cols))}
+	for g := range w.groups {
+		if w.groups[g].name == name {
+			w.groups[g] = group
+			return
+		}
+	}
+	w.groups = append(w.groups, group)
+}
+
+// WithColumnGroup defines a collapsed group of columns. See [Writer.SetColumnGroup]
+func WithColumnGroup(name string, cols ...int) Option {
+	return func(w *Writer) {
+		w.SetColumnGroup(name, cols...)
+	}
+}
+
+// ExpandColumnGroup expands or collapses the group of columns with the given name, starting from the next flush
+func (w *Writer) ExpandColumnGroup(name string, expanded bool) {
+	for g := range w.groups {
+		if w.groups[g].name == name {
+			w.groups[g].expanded = expanded
+		}
+	}
+}
+
+// collapseGroups replaces the fields of each collapsed group's first column with the group's placeholder, which
+// consists of the group's name in the header and of empty data fields
+func (w *Writer) collapseGroups() {
+	marker := w.glyph(groupMarker)
+	if w.flags&AsciiTable != 0 {
+		marker = asciiGroupMarker
+	}
+	for _, group := range w.groups {
+		if group.expanded || len(group.cols) == 0 {
+			continue
+		}
+		for r, cells := range w.rows {
+			if first := group.cols[0]; first < len(cells) {
+				cells[first] = cell{}
+				if r == 0 {
+					cells[first] = cell{text: marker + group.name, plain: marker + group.name}
+				}
+			}
+		}
+	}
+}
+
+// isCollapsed reports whether the column at the given index is hidden by a collapsed group
+func (w *Writer) isCollapsed(c int) bool {
+	for _, group := range w.groups {
+		if !group.expanded && len(group.cols) > 1 && slices.Contains(group.cols[1:], c) {
+			return true
+		}
+	}
+	return false
+}
+
+// inCollapsedGroup reports whether the column at the given index belongs to a collapsed group, whose fields are
+// never displayed
+func (w *Writer) inCollapsedGroup(c int) bool {
+	for _, group := range w.groups {
+		if !group.expanded && slices.Contains(group.cols, c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package TableWriter
+
+// GuideMode defines how the guides drawn every N data rows look like
+type GuideMode uint
+
+const (
+	// GuideSeparator draws a thicker horizontal line
+	GuideSeparator GuideMode = iota
+	// GuideHeader repeats the header row
+	GuideHeader
+)
+
+// SetRowGuides draws a guide after every N data rows, helping readers to keep track of rows in long tables.
+// Zero disables the guides
+func (w *Writer) SetRowGuides(every int, mode GuideMode) {
+	w.guideEvery = max(every, 0)
+	w.guideMode = mode
+}
+
+// WithRowGuides draws a guide after every N data rows. See [Writer.SetRowGuides]
+func WithRowGuides(every int, mode GuideMode) Option {
+	return func(w *Writer) {
+		w.SetRowGuides(every, mode)
+	}
+}
+
+// isGuideRow reports whether a guide must be drawn after the row at the given index.
+// No guides are drawn after the header or at the end of the table
+func (w *Writer) isGuideRow(l int, isLastRow bool) bool {
+	return w.guideEvery > 0 && l > 0 && !isLastRow && l%w.guideEvery == 0
+}
+
+// guideGlyphs maps the horizontal lines and junctions of the styles to the thicker ones drawn by the guides.
+// Double lines are thickened into heavy ones and vice versa, while the junctions lacking a thicker variant are kept
+var guideGlyphs = map[string]string{
+	"-": "=", "─": "━", "┄": "┅", "━": "═", "═": "━",
+	"├": "┝", "┼": "┿", "┤": "┥",
+}
+
+// thickerGlyph returns the thicker version of the given divider, or the divider itself if it has none
+func thickerGlyph(divider string) string {
+	if thicker, ok := guideGlyphs[divider]; ok {
+		return thicker
+	}
+	return divider
+}
+
+// guideDividers returns the dividers used to draw thicker separators, derived from the current ones, so that the
+// guides match the selected [Style], [Frame] and [Borders] as well as the custom dividers
+func (w *Writer) guideDividers() Dividers {
+	guide := w.divider
+	guide.HLine = thickerGlyph(guide.HLine)
+	guide.VLeft = thickerGlyph(guide.VLeft)
+	guide.Cross = thickerGlyph(guide.Cross)
+	guide.VRight = thickerGlyph(guide.VRight)
+	return guide
+}
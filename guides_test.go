@@ -0,0 +1,33 @@
+package TableWriter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGuideDividersMatchStyle(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	tests := []struct {
+		name  string
+		flags uint
+		opts  []Option
+		want  string
+	}{
+		{name: "default", want: "┝━━┿━━┥"},
+		{name: "double", opts: []Option{WithStyle(StyleDouble)}, want: "╠━━╬━━╣"},
+		{name: "heavy", opts: []Option{WithStyle(StyleHeavy)}, want: "┣══╋══┫"},
+		{name: "dotted", opts: []Option{WithStyle(StyleDotted)}, want: "┝┅┅┿┅┅┥"},
+		{name: "double frame", opts: []Option{WithFrame(FrameDouble)}, want: "╟━━┿━━╢"},
+		{name: "ascii", flags: AsciiTable, want: "+==+==+"},
+		{name: "copy friendly", flags: CopyFriendly, want: "━━━━━━━"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append(tt.opts, WithWidth(40), WithRowGuides(1, GuideSeparator))
+			lines := strings.Split(renderTable(t, "A\tB\n1\t2\n3\t4\n", StripColours|tt.flags, opts...), "\n")
+			if len(lines) < 5 || lines[4] != tt.want {
+				t.Errorf("guide = %q, want %q", lines[min(4, len(lines)-1)], tt.want)
+			}
+		})
+	}
+}
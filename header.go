@@ -0,0 +1,58 @@
+package TableWriter
+
+import (
+	"strings"
+	"text/template"
+)
+
+// RenameColumn replaces, at render time, each header field whose colorless text equals oldName with newName.
+// The new name can contain template placeholders, resolved as described in [Writer.SetMetadata].
+// Output formats and exports use the renamed header as well
+func (w *Writer) RenameColumn(oldName, newName string) {
+	w.renames[oldName] = newName
+}
+
+// SetMetadata stores a value that header fields can refer to through [text/template] placeholders.
+// For example, the header "Size ({{.Unit}})" is rendered as "Size (MB)" after calling SetMetadata("Unit", "MB")
+func (w *Writer) SetMetadata(key string, value any) {
+	w.metadata[key] = value
+}
+
+// WithMetadata stores a value that header fields can refer to. See [Writer.SetMetadata]
+func WithMetadata(key string, value any) Option {
+	return func(w *Writer) {
+		w.SetMetadata(key, value)
+	}
+}
+
+// renderHeader applies the renamed columns and resolves the templates of the header's fields.
+// Fields whose template cannot be resolved are left untouched
+func (w *Writer) renderHeader() {
+	if len(w.rows) == 0 {
+		return
+	}
+	header := w.rows[0]
+	for c := range header {
+		header[c].text = w.renamedHeader(header[c].text, header[c].plain)
+		header[c].plain = stripEscapeCodes(header[c].text)
+		if w.flags&StripColours != 0 {
+			header[c].text = header[c].plain
+		}
+	}
+}
+
+// renamedHeader returns the given header field, whose colorless text is plain, with its column renamed and its
+// template resolved
+func (w *Writer) renamedHeader(text, plain string) string {
+	if newName, ok := w.renames[plain]; ok {
+		text = newName
+	}
+	if strings.Contains(text, "{{") {
+		var sb strings.Builder
+		tmpl, err := template.New("header").Option("missingkey=error").Parse(text)
+		if err == nil && tmpl.Execute(&sb, w.metadata) == nil {
+			text = sb.String()
+		}
+	}
+	return text
+}
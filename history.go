@@ -0,0 +1,43 @@
+package TableWriter
+
+// history is a bounded ring buffer holding the most recent frames rendered by the [Writer]
+type history struct {
+	frames [][]byte
+	next   int // Index of the slot receiving the next frame
+	count  int // Number of frames stored so far, up to the buffer's size
+}
+
+// SetHistorySize keeps the given number of past rendered frames, which can be retrieved with [Writer.History].
+// Watch tools can use them to pause and scroll back through previous snapshots. Zero disables the history and
+// discards the frames already stored
+func (w *Writer) SetHistorySize(size int) {
+	w.history = history{frames: make([][]byte, max(size, 0))}
+}
+
+// WithHistorySize keeps the given number of past rendered frames. See [Writer.SetHistorySize]
+func WithHistorySize(size int) Option {
+	return func(w *Writer) {
+		w.SetHistorySize(size)
+	}
+}
+
+// History returns the frame rendered n flushes ago, where 0 is the last one, and reports whether it is available.
+// Frames consist of the table as written to the output, without the sequences used to manage the terminal's screen
+func (w *Writer) History(n int) ([]byte, bool) {
+	if n < 0 || n >= w.history.count {
+		return nil, false
+	}
+	size := len(w.history.frames)
+	return w.history.frames[(w.history.next-1-n+size)%size], true
+}
+
+// recordFrame stores a copy of the given frame into the history, replacing the oldest one when it is full
+func (w *Writer) recordFrame(frame []byte) {
+	size := len(w.history.frames)
+	if size == 0 {
+		return
+	}
+	w.history.frames[w.history.next] = append([]byte(nil), frame...)
+	w.history.next = (w.history.next + 1) % size
+	w.history.count = min(w.history.count+1, size)
+}
@@ -0,0 +1,271 @@
+package TableWriter
+
+import (
+	"cmp"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ansiPalette holds the CSS colors of the 16 standard ANSI colors, as displayed by xterm
+var ansiPalette = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// color256 returns the CSS color of the given entry of the 256 colors palette
+func color256(n int) string {
+	switch {
+	case n < 16:
+		return ansiPalette[n]
+	case n < 232:
+		levels := [6]int{0, 95, 135, 175, 215, 255}
+		n -= 16
+		return fmt.Sprintf("#%02x%02x%02x", levels[n/36], levels[n/6%6], levels[n%6])
+	default:
+		gray := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
+
+// sgrColor is a color set by SGR sequences: either an entry of the 256 colors palette, whose first 16 entries are
+// the standard ANSI colors, or a true color. The zero value is the terminal's default color
+type sgrColor struct {
+	set   bool
+	rgb   bool
+	value uint32 // Palette entry, or 0xRRGGBB for true colors
+}
+
+// css returns the CSS color equivalent to the color, or an empty string for the default one
+func (c sgrColor) css() string {
+	switch {
+	case !c.set:
+		return ""
+	case c.rgb:
+		return fmt.Sprintf("#%06x", c.value&0xffffff)
+	default:
+		return color256(int(c.value))
+	}
+}
+
+// sgrStyle is the styling of a field's text, set by its SGR sequences
+type sgrStyle struct {
+	bold, dim, italic, underline, strike, inverse bool
+	fg, bg                                        sgrColor
+}
+
+// extendedColor parses the 256 colors (5;n) and true colors (2;r;g;b) parameters following 38 or 48, returning the
+// color and the number of parameters consumed
+func extendedColor(params []int) (sgrColor, int) {
+	switch {
+	case len(params) >= 2 && params[0] == 5:
+		return sgrColor{set: true, value: uint32(min(max(params[1], 0), 255))}, 2
+	case len(params) >= 4 && params[0] == 2:
+		return sgrColor{set: true, rgb: true, value: uint32(params[1]&0xff)<<16 | uint32(params[2]&0xff)<<8 |
+			uint32(params[3]&0xff)}, 4
+	default:
+		return sgrColor{}, len(params)
+	}
+}
+
+// apply updates the style according to the given SGR sequence. Unsupported attributes (e.g. blinking) are ignored,
+// and it reports whether the resulting style is missing any of them
+func (s *sgrStyle) apply(seq string) bool {
+	fields := strings.FieldsFunc(seq[2:len(seq)-1], func(r rune) bool { return r == ';' || r == ':' })
+	params := make([]int, 0, max(len(fields), 1))
+	for _, field := range fields {
+		n, _ := strconv.Atoi(field)
+		params = append(params, n)
+	}
+	if len(params) == 0 {
+		params = append(params, 0)
+	}
+	exact := true
+	for i := 0; i < len(params); i++ {
+		switch p := params[i]; {
+		case p == 0:
+			*s = sgrStyle{}
+			exact = true
+		case p == 1:
+			s.bold = true
+		case p == 2:
+			s.dim = true
+		case p == 3:
+			s.italic = true
+		case p == 4:
+			s.underline = true
+		case p == 7:
+			s.inverse = true
+		case p == 9:
+			s.strike = true
+		case p == 22:
+			s.bold, s.dim = false, false
+		case p == 23:
+			s.italic = false
+		case p == 24:
+			s.underline = false
+		case p == 27:
+			s.inverse = false
+		case p == 29:
+			s.strike = false
+		case p >= 30 && p <= 37:
+			s.fg = sgrColor{set: true, value: uint32(p - 30)}
+		case p >= 90 && p <= 97:
+			s.fg = sgrColor{set: true, value: uint32(p - 90 + 8)}
+		case p == 39:
+			s.fg = sgrColor{}
+		case p >= 40 && p <= 47:
+			s.bg = sgrColor{set: true, value: uint32(p - 40)}
+		case p >= 100 && p <= 107:
+			s.bg = sgrColor{set: true, value: uint32(p - 100 + 8)}
+		case p == 49:
+			s.bg = sgrColor{}
+		case p == 38 || p == 48:
+			color, consumed := extendedColor(params[i+1:])
+			if p == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+			i += consumed
+		default:
+			exact = false
+		}
+	}
+	return exact
+}
+
+// css returns the inline CSS declarations equivalent to the style
+func (s sgrStyle) css() string {
+	fg, bg := s.fg.css(), s.bg.css()
+	if s.inverse {
+		fg, bg = cmp.Or(bg, "white"), cmp.Or(fg, "black")
+	}
+	declarations := make([]string, 0)
+	if fg != "" {
+		declarations = append(declarations, "color:"+fg)
+	}
+	if bg != "" {
+		declarations = append(declarations, "background-color:"+bg)
+	}
+	if s.bold {
+		declarations = append(declarations, "font-weight:bold")
+	}
+	if s.dim {
+		declarations = append(declarations, "opacity:0.6")
+	}
+	if s.italic {
+		declarations = append(declarations, "font-style:italic")
+	}
+	decorations := make([]string, 0)
+	if s.underline {
+		decorations = append(decorations, "underline")
+	}
+	if s.strike {
+		decorations = append(decorations, "line-through")
+	}
+	if len(decorations) > 0 {
+		declarations = append(declarations, "text-decoration:"+strings.Join(decorations, " "))
+	}
+	return strings.Join(declarations, ";")
+}
+
+// hyperlinkTarget returns the URI of the given hyperlink sequence, which is empty for the sequences closing a link
+func hyperlinkTarget(seq string) string {
+	_, uri, _ := strings.Cut(seq[len("\x1b]8;"):], ";")
+	return strings.TrimSuffix(strings.TrimSuffix(uri, "\a"), "\x1b\\")
+}
+
+// safeLink returns the URI of the given hyperlink sequence if it can be safely embedded in a web page, which
+// excludes schemes like javascript:
+func safeLink(seq string) (string, bool) {
+	uri := hyperlinkTarget(seq)
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto", "ftp", "file":
+		return uri, true
+	default:
+		return "", false
+	}
+}
+
+// htmlField converts the given field into HTML: its text is escaped, line breaks become <br> elements, SGR styles
+// become spans with inline CSS and terminal hyperlinks become anchors. All the other escape sequences are dropped
+func htmlField(field string) string {
+	var sb strings.Builder
+	var style sgrStyle
+	var link string
+	for segment, escape := range escapeSegments(field) {
+		switch {
+		case escape && strings.HasPrefix(segment, "\x1b[") && strings.HasSuffix(segment, "m"):
+			style.apply(segment)
+		case escape && strings.HasPrefix(segment, "\x1b]8;"):
+			if link != "" {
+				sb.WriteString("</a>")
+			}
+			link, _ = safeLink(segment)
+			if link != "" {
+				sb.WriteString(`<a href="` + html.EscapeString(link) + `">`)
+			}
+		case !escape:
+			text := strings.ReplaceAll(html.EscapeString(segment), "\n", "<br>")
+			if css := style.css(); css != "" {
+				text = `<span style="` + css + `">` + text + "</span>"
+			}
+			sb.WriteString(text)
+		}
+	}
+	if link != "" {
+		sb.WriteString("</a>")
+	}
+	return sb.String()
+}
+
+// renderHTML renders the parsed rows as an HTML table, whose first row is the header. Rows are completed with empty
+// cells, so that they all have the same number of columns
+func (w *Writer) renderHTML() []byte {
+	columns := 0
+	for _, cells := range w.rows {
+		columns = max(columns, len(cells))
+	}
+	var sb strings.Builder
+	writeRow := func(cells []cell, tag string) {
+		sb.WriteString("<tr>")
+		for c := range columns {
+			sb.WriteString("<" + tag)
+			switch w.columnAlignment(c) {
+			case Center:
+				sb.WriteString(` style="text-align:center"`)
+			case Right:
+				sb.WriteString(` style="text-align:right"`)
+			}
+			sb.WriteString(">")
+			if c < len(cells) {
+				sb.WriteString(htmlField(cells[c].text))
+			}
+			sb.WriteString("</" + tag + ">")
+		}
+		sb.WriteString("</tr>\n")
+	}
+
+	sb.WriteString("<table>\n")
+	if len(w.rows) > 0 {
+		sb.WriteString("<thead>\n")
+		writeRow(w.rows[0], "th")
+		sb.WriteString("</thead>\n")
+	}
+	if len(w.rows) > 1 {
+		sb.WriteString("<tbody>\n")
+		for _, cells := range w.rows[1:] {
+			writeRow(cells, "td")
+		}
+		sb.WriteString("</tbody>\n")
+	}
+	sb.WriteString("</table>\n")
+	return []byte(sb.String())
+}
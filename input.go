@@ -0,0 +1,72 @@
+package TableWriter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CarriageReturnPolicy defines how bare carriage returns (\r not followed by \n) found in the input are handled.
+// Windows line endings (\r\n) are always treated as simple line breaks
+type CarriageReturnPolicy uint
+
+const (
+	// CarriageReturnStrip removes bare carriage returns, like any other control character
+	CarriageReturnStrip CarriageReturnPolicy = iota
+	// CarriageReturnReset treats bare carriage returns as line resets, like terminals do with progress lines:
+	// only the content following the last one is kept
+	CarriageReturnReset
+	// CarriageReturnSplit treats bare carriage returns as line breaks
+	CarriageReturnSplit
+)
+
+// SetCarriageReturnPolicy defines how bare carriage returns found in the input are handled
+func (w *Writer) SetCarriageReturnPolicy(policy CarriageReturnPolicy) {
+	w.crPolicy = policy
+}
+
+// WithCarriageReturnPolicy defines how bare carriage returns found in the input are handled.
+// See [Writer.SetCarriageReturnPolicy]
+func WithCarriageReturnPolicy(policy CarriageReturnPolicy) Option {
+	return func(w *Writer) {
+		w.SetCarriageReturnPolicy(policy)
+	}
+}
+
+// parseCarriageReturnPolicy converts the name of a policy, as accepted by [OptionsFromArgs], into its value
+func parseCarriageReturnPolicy(value string) (CarriageReturnPolicy, error) {
+	switch value {
+	case "strip":
+		return CarriageReturnStrip, nil
+	case "reset":
+		return CarriageReturnReset, nil
+	case "split":
+		return CarriageReturnSplit, nil
+	default:
+		return 0, fmt.Errorf("invalid carriage return policy %q", value)
+	}
+}
+
+// normalizeCarriageReturns converts Windows line endings and processes bare carriage returns according to the
+// [CarriageReturnPolicy]
+func (w *Writer) normalizeCarriageReturns(buffer string) string {
+	buffer = strings.ReplaceAll(buffer, "\r\n", "\n")
+	switch w.crPolicy {
+	case CarriageReturnSplit:
+		return strings.ReplaceAll(buffer, "\r", "\n")
+	case CarriageReturnReset:
+		lines := strings.Split(buffer, "\n")
+		for i, line := range lines {
+			// A trailing carriage return does not erase the line, until something else is written
+			parts := strings.Split(strings.TrimRight(line, "\r"), "\r")
+			lines[i] = parts[len(parts)-1]
+		}
+		return strings.Join(lines, "\n")
+	default:
+		return buffer
+	}
+}
+
+// cleanBuffer returns the given buffered data, ready to be split into rows and fields
+func (w *Writer) cleanBuffer(data []byte) string {
+	return w.cleanInvisibleChars(w.normalizeCarriageReturns(string(data)))
+}
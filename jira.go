@@ -0,0 +1,27 @@
+package TableWriter
+
+import (
+	"cmp"
+	"strings"
+)
+
+// jiraEscaper escapes the cell separators of Jira tables and turns line breaks into forced ones
+var jiraEscaper = strings.NewReplacer("|", `\|`, "\n", `\\`)
+
+// renderJira renders the parsed rows as a Jira/Confluence wiki markup table, whose first row is the header.
+// Empty fields are rendered as a space, since Jira would otherwise merge their separators
+func (w *Writer) renderJira() []byte {
+	var sb strings.Builder
+	for r, row := range w.plainRows() {
+		separator := "|"
+		if r == 0 {
+			separator = "||"
+		}
+		sb.WriteString(separator)
+		for _, field := range row {
+			sb.WriteString(cmp.Or(jiraEscaper.Replace(field), " ") + separator)
+		}
+		sb.WriteByte('\n')
+	}
+	return []byte(sb.String())
+}
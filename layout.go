@@ -0,0 +1,39 @@
+package TableWriter
+
+import "slices"
+
+// ColumnWidths returns the widths chosen for the columns' text by the last render, excluding padding and borders.
+// Hidden columns have zero width. Together with [Writer.SetColumnWidths], it allows separate processes or Writers
+// (e.g. a producer rendering the header and consumers rendering the rows later) to lay out their tables exactly
+// in the same way
+func (w *Writer) ColumnWidths() []int {
+	return slices.Clone(w.layout)
+}
+
+// SetColumnWidths forces the widths of the columns' text, regardless of their content and of the terminal's width.
+// Fields exceeding them are processed according to their column's [TruncatePolicy].
+// Zero or negative widths, like the columns exceeding the given ones, leave the width of the column unchanged.
+// Calling it with no widths restores the automatic layout
+func (w *Writer) SetColumnWidths(widths []int) {
+	w.fixedWidths = slices.Clone(widths)
+}
+
+// WithColumnWidths forces the widths of the columns' text. See [Writer.SetColumnWidths]
+func WithColumnWidths(widths []int) Option {
+	return func(w *Writer) {
+		w.SetColumnWidths(widths)
+	}
+}
+
+// forceColumns applies the widths set by [Writer.SetColumnWidths] to the columns laid out by the current render
+func (w *Writer) forceColumns() {
+	if len(w.fixedWidths) == 0 {
+		return
+	}
+	for c := range min(len(w.columns), len(w.fixedWidths)) {
+		if w.fixedWidths[c] > 0 {
+			w.columns[c].textWidth = w.fixedWidths[c]
+		}
+	}
+	w.debug("columns' widths forced", "widths", w.columnWidths())
+}
@@ -0,0 +1,83 @@
+package TableWriter
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// AppendRow adds a row whose fields are evaluated at render time, after the data written so far.
+// Fields can be strings, [fmt.Stringer] values or func() string, while any other value is formatted with [fmt.Sprint].
+// Fields belonging to columns hidden by collapsed groups or responsive presets are never evaluated, saving the
+// formatting of values that would not be displayed. Tabs and line breaks do not need to be escaped
+func (w *Writer) AppendRow(fields ...any) error {
+	// The row is buffered as an empty line, whose position is tracked out of band, so that it can never be forged
+	line := []byte{'\n'}
+	if len(w.buffer) > 0 && w.buffer[len(w.buffer)-1] != '\n' {
+		line = []byte("\n\n")
+	}
+	size := len(w.buffer)
+	if _, err := w.Write(line); err != nil {
+		return err
+	}
+	// Rows discarded by the spill policy are never evaluated
+	if w.spillPolicy == DropNewest && len(w.buffer) == size {
+		return nil
+	}
+	w.lazyRows[countLines(w.buffer)-1] = fields
+	return nil
+}
+
+// bufferedRows splits the buffered data into rows, along with the values of the rows added by [Writer.AppendRow],
+// which are nil for any other row. The fields of the lazy rows are left to be resolved by [Writer.resolveRow]
+func (w *Writer) bufferedRows() ([][]string, [][]any) {
+	rows, values := make([][]string, 0), make([][]any, 0)
+	// Consecutive regular lines are cleaned and split together
+	var pending []byte
+	split := func() {
+		for _, fields := range w.splitRows(w.cleanBuffer(pending)) {
+			rows, values = append(rows, fields), append(values, nil)
+		}
+		pending = pending[:0]
+	}
+	i := 0
+	for line := range bytes.Lines(w.buffer) {
+		if lazy, ok := w.lazyRows[i]; ok {
+			split()
+			rows, values = append(rows, nil), append(values, lazy)
+		} else {
+			pending = append(pending, line...)
+		}
+		i++
+	}
+	split()
+	return rows, values
+}
+
+// resolveRow evaluates the given values of a row added by [Writer.AppendRow] into its fields.
+// When skipHidden is set, the fields of the columns that are known to be hidden are left empty
+func (w *Writer) resolveRow(values []any, skipHidden bool) []string {
+	// The kind of the rows rendered by templates is not a field
+	if len(values) > 0 {
+		if _, ok := values[0].(rowKind); ok {
+			values = values[1:]
+		}
+	}
+	resolved := make([]string, len(values))
+	for c, value := range values {
+		if skipHidden && (w.inCollapsedGroup(c) || w.isResponsiveHidden(c)) {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			resolved[c] = v
+		case func() string:
+			resolved[c] = v()
+		case fmt.Stringer:
+			resolved[c] = v.String()
+		default:
+			resolved[c] = fmt.Sprint(v)
+		}
+		resolved[c] = EscapeCell(resolved[c])
+	}
+	return resolved
+}
@@ -0,0 +1,54 @@
+package TableWriter
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestAppendRow(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	var buf bytes.Buffer
+	w := NewWriter(&buf, StripColours, WithWidth(40))
+	evaluated := false
+	// Control bytes in the written data must never be mistaken for lazy rows
+	if _, err := io.WriteString(w, "Name\tValue\n\x1d0\tforged\n"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.AppendRow("lazy", func() string { evaluated = true; return "a\tb" }); err != nil {
+		t.Fatalf("AppendRow() error = %v", err)
+	}
+	// Incomplete lines are terminated before the lazy row
+	if _, err := io.WriteString(w, "partial"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.AppendRow(1, 2.5); err != nil {
+		t.Fatalf("AppendRow() error = %v", err)
+	}
+
+	want := [][]string{{"0", "forged"}, {"lazy", "a\tb"}, {"partial"}, {"1", "2.5"}}
+	if got := w.Model().Rows; !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("Model().Rows = %q, want %q", got, want)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if !evaluated {
+		t.Error("the lazy field was never evaluated")
+	}
+	wantTable := "┌────────┬───────┐\n" +
+		"│Name    │Value  │\n" +
+		"├────────┼───────┤\n" +
+		"│0       │forged │\n" +
+		"├────────┼───────┤\n" +
+		"│lazy    │a b    │\n" +
+		"├────────┼───────┤\n" +
+		"│partial │\n" +
+		"├────────┤\n" +
+		"│1       │2.5    │\n" +
+		"└────────┴───────┘\n"
+	if got := buf.String(); got != wantTable {
+		t.Errorf("got:\n%s\nwant:\n%s", got, wantTable)
+	}
+}
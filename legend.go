@@ -0,0 +1,53 @@
+package TableWriter
+
+import "strings"
+
+// legendEntry identifies a coloring applied by the Writer, explained by the legend rendered when [ShowLegend] is set
+type legendEntry uint
+
+const (
+	legendChanged legendEntry = 1 << iota
+	legendIncreased
+	legendDecreased
+	legendTruncated
+)
+
+// Sample characters displayed by the legend in the color of the changed fields
+const (
+	changedSample      = "■"
+	asciiChangedSample = "#"
+)
+
+// useLegendEntry records that the given coloring has been applied by the current render
+func (w *Writer) useLegendEntry(entry legendEntry) {
+	w.legend |= entry
+}
+
+// renderLegend returns the legend to be rendered below the table, explaining the colorings applied by the current
+// render, if any. The legend is omitted in [FollowMode]
+func (w *Writer) renderLegend() []byte {
+	if w.flags&ShowLegend == 0 || w.flags&FollowMode != 0 || w.legend == 0 {
+		return nil
+	}
+	changed, increased, decreased := w.glyph(changedSample), w.glyph(trendUp), w.glyph(trendDown)
+	if w.flags&AsciiTable != 0 {
+		changed, increased, decreased = asciiChangedSample, asciiTrendUp, asciiTrendDown
+	}
+	entries := []struct {
+		entry  legendEntry
+		sample string
+		label  string
+	}{
+		{legendChanged, colorYellow + changed + colorReset, "changed"},
+		{legendIncreased, colorGreen + increased + colorReset, "increased"},
+		{legendDecreased, colorRed + decreased + colorReset, "decreased"},
+		{legendTruncated, colorOrange + truncationSuffix + colorReset, "truncated"},
+	}
+	legend := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if w.legend&e.entry != 0 {
+			legend = append(legend, e.sample+" "+e.label)
+		}
+	}
+	return []byte(strings.Join(legend, "   ") + "\n")
+}
@@ -0,0 +1,43 @@
+package TableWriter
+
+import "bytes"
+
+// LineEnding defines the sequence terminating the lines written by the [Writer]
+type LineEnding uint
+
+const (
+	// LineEndingLF terminates the lines with a line feed, as expected by Unix terminals
+	LineEndingLF LineEnding = iota
+	// LineEndingCRLF terminates the lines with a carriage return and a line feed, as expected by Windows consoles,
+	// SMTP bodies and serial devices
+	LineEndingCRLF
+)
+
+// SetLineEnding defines the sequence terminating all the written lines, including the borders and dividers of the
+// table, the lines following it and the exported formats
+func (w *Writer) SetLineEnding(ending LineEnding) {
+	w.lineEnding = ending
+}
+
+// WithLineEnding defines the sequence terminating all the written lines. See [Writer.SetLineEnding]
+func WithLineEnding(ending LineEnding) Option {
+	return func(w *Writer) {
+		w.lineEnding = ending
+	}
+}
+
+// applyLineEnding terminates the lines of the given output according to the selected [LineEnding].
+// Lines already terminated by CRLF are left untouched
+func (w *Writer) applyLineEnding(output []byte) []byte {
+	if w.lineEnding != LineEndingCRLF || !bytes.Contains(output, []byte{'\n'}) {
+		return output
+	}
+	converted := make([]byte, 0, len(output)+bytes.Count(output, []byte{'\n'}))
+	for i, b := range output {
+		if b == '\n' && (i == 0 || output[i-1] != '\r') {
+			converted = append(converted, '\r')
+		}
+		converted = append(converted, b)
+	}
+	return converted
+}
@@ -0,0 +1,38 @@
+package TableWriter
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SetLogger registers the logger used to trace the layout decisions taken while rendering tables, such as the chosen
+// widths, the applied truncations and the dropped columns. Messages are logged at debug level.
+// Passing nil disables tracing
+func (w *Writer) SetLogger(logger *slog.Logger) {
+	w.logger = logger
+}
+
+// WithLogger registers the logger used to trace the layout decisions. See [Writer.SetLogger]
+func WithLogger(logger *slog.Logger) Option {
+	return func(w *Writer) {
+		w.SetLogger(logger)
+	}
+}
+
+// debug logs a layout decision, if a logger has been registered
+func (w *Writer) debug(msg string, args ...any) {
+	if w.logger != nil {
+		w.logger.Log(context.Background(), slog.LevelDebug, msg, args...)
+	}
+}
+
+// columnWidths returns the current width of each column, for tracing purposes. Hidden columns have a zero width
+func (w *Writer) columnWidths() []int {
+	widths := make([]int, len(w.columns))
+	for c := range w.columns {
+		if !w.columns[c].hidden {
+			widths[c] = w.columns[c].textWidth
+		}
+	}
+	return widths
+}
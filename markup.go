@@ -0,0 +1,60 @@
+package TableWriter
+
+import "strings"
+
+// ANSI styles translating the inline markup enabled by [InlineMarkup]
+const (
+	boldStart = "\033[1m"
+	dimStart  = "\033[2m"
+	styleEnd  = "\033[22m"
+	codeStart = "\033[36m"
+	codeEnd   = "\033[39m"
+)
+
+// isWordByte reports whether the given byte belongs to a word, so that underscores within identifiers like
+// snake_case are not mistaken for markup. Bytes of multibyte characters are considered letters
+func isWordByte(b byte) bool {
+	return b >= 0x80 || b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// renderMarkup translates the inline markup of the given field into ANSI styles: **bold**, _dim_ and `code`.
+// The content of code spans is never translated, while unpaired delimiters are kept as they are
+func renderMarkup(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end > 0 {
+				sb.WriteString(codeStart + s[i+1:i+1+end] + codeEnd)
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(s[i:], "**"):
+			if end := strings.Index(s[i+2:], "**"); end > 0 {
+				sb.WriteString(boldStart + renderMarkup(s[i+2:i+2+end]) + styleEnd)
+				i += end + 4
+				continue
+			}
+		case s[i] == '_' && (i == 0 || !isWordByte(s[i-1])):
+			if end := closingUnderscore(s, i); end > 0 {
+				sb.WriteString(dimStart + renderMarkup(s[i+1:end]) + styleEnd)
+				i = end + 1
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+		i++
+	}
+	return sb.String()
+}
+
+// closingUnderscore returns the position of the underscore closing the one at the given position, which must end a
+// word as well, or -1 if there is none
+func closingUnderscore(s string, start int) int {
+	for end := start + 2; end < len(s); end++ {
+		if s[end] == '_' && (end+1 == len(s) || !isWordByte(s[end+1])) {
+			return end
+		}
+	}
+	return -1
+}
@@ -0,0 +1,91 @@
+package TableWriter
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// RenderStats describes the work performed by a single call to [Writer.Flush]
+type RenderStats struct {
+	// Rows is the number of data rows rendered, header excluded
+	Rows int
+	// Columns is the number of columns displayed, hidden ones excluded
+	Columns int
+	// Bytes is the number of bytes written to the output
+	Bytes int
+	// Truncations is the number of fields that did not fit their column, and have been truncated or wrapped
+	Truncations int
+	// LayoutDuration is the time spent computing the columns' widths and truncating the fields
+	LayoutDuration time.Duration
+	// RenderDuration is the total time spent by the flush
+	RenderDuration time.Duration
+}
+
+// SetStatsHook registers a callback invoked after every flush with the statistics of the render, so that services
+// embedding the [Writer] can monitor its output costs. See [Metrics] for a ready-to-use collector
+func (w *Writer) SetStatsHook(hook func(RenderStats)) {
+	w.statsHook = hook
+}
+
+// WithStatsHook registers a callback invoked after every flush with the statistics of the render.
+// See [Writer.SetStatsHook]
+func WithStatsHook(hook func(RenderStats)) Option {
+	return func(w *Writer) {
+		w.SetStatsHook(hook)
+	}
+}
+
+// Metrics accumulates the statistics of multiple renders and is safe for concurrent use.
+// It implements the [expvar.Var] interface, so it can be published as is:
+//
+//	metrics := new(TableWriter.Metrics)
+//	expvar.Publish("tables", metrics)
+//	w.SetStatsHook(metrics.Observe)
+type Metrics struct {
+	mu     sync.Mutex
+	totals MetricsSnapshot
+}
+
+// MetricsSnapshot holds the totals accumulated by [Metrics] at a given time
+type MetricsSnapshot struct {
+	Renders        int64
+	Rows           int64
+	Bytes          int64
+	Truncations    int64
+	LayoutDuration time.Duration
+	RenderDuration time.Duration
+}
+
+// Observe adds the statistics of a render to the totals
+func (m *Metrics) Observe(stats RenderStats) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.totals.Renders++
+	m.totals.Rows += int64(stats.Rows)
+	m.totals.Bytes += int64(stats.Bytes)
+	m.totals.Truncations += int64(stats.Truncations)
+	m.totals.LayoutDuration += stats.LayoutDuration
+	m.totals.RenderDuration += stats.RenderDuration
+}
+
+// Snapshot returns the totals accumulated so far, which can be read while other renders are being observed
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.totals
+}
+
+// String returns the totals encoded as a JSON object, with durations expressed in nanoseconds
+func (m *Metrics) String() string {
+	totals := m.Snapshot()
+	data, _ := json.Marshal(map[string]int64{
+		"renders":         totals.Renders,
+		"rows":            totals.Rows,
+		"bytes":           totals.Bytes,
+		"truncations":     totals.Truncations,
+		"layout_duration": int64(totals.LayoutDuration),
+		"render_duration": int64(totals.RenderDuration),
+	})
+	return string(data)
+}
@@ -0,0 +1,36 @@
+package TableWriter
+
+import (
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestMetricsConcurrentUse(t *testing.T) {
+	metrics := new(Metrics)
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := NewWriter(io.Discard, StripColours, WithWidth(40), WithStatsHook(metrics.Observe))
+			for range 10 {
+				_, _ = io.WriteString(w, "name\tid\nalice\t1\nbob\t2\n")
+				if err := w.Flush(); err != nil {
+					t.Errorf("Flush() error = %v", err)
+				}
+				_ = metrics.Snapshot()
+				_ = metrics.String()
+			}
+		}()
+	}
+	wg.Wait()
+
+	totals := metrics.Snapshot()
+	if totals.Renders != 40 || totals.Rows != 80 {
+		t.Errorf("Snapshot() = %+v, want 40 renders of 80 rows", totals)
+	}
+	if totals.Bytes == 0 || totals.RenderDuration == 0 {
+		t.Errorf("Snapshot() = %+v, want the bytes and the durations accumulated", totals)
+	}
+}
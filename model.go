@@ -0,0 +1,131 @@
+package TableWriter
+
+import (
+	"io"
+	"strings"
+)
+
+// Model is the tabular representation of the data received by a [Writer].
+// The first row received is the Header, while all the following ones are the data Rows
+type Model struct {
+	Header []string
+	Rows   [][]string
+}
+
+// Model parses the data buffered so far into a [Model], without consuming it.
+// Fields keep their ANSI color codes, while the values escaped by [EscapeCell] are restored
+func (w *Writer) Model() *Model {
+	m := &Model{Rows: make([][]string, 0)}
+	rows, values := w.bufferedRows()
+	for r, fields := range rows {
+		if values[r] != nil {
+			fields = w.resolveRow(values[r], false)
+			rows[r] = fields
+		}
+		for c := range fields {
+			fields[c] = unescapeCell(fields[c])
+		}
+	}
+	if len(rows) > 0 {
+		m.Header = rows[0]
+		m.Rows = rows[1:]
+	}
+	return m
+}
+
+// column returns the colorless values held by the column at the given index, one per data row.
+// Rows that are too short to contain the column yield empty values
+func (m *Model) column(col int) []string {
+	values := make([]string, len(m.Rows))
+	for r, row := range m.Rows {
+		if col < len(row) {
+			values[r] = stripEscapeCodes(row[col])
+		}
+	}
+	return values
+}
+
+// WriteTo sends the model to out as tab-separated lines, so that it can be rendered by a [Writer].
+// Fields are escaped with [EscapeCell], so that tabs and line breaks do not split them.
+// It implements the [io.WriterTo] interface
+func (m *Model) WriteTo(out io.Writer) (n int64, err error) {
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		for c, field := range row {
+			if c > 0 {
+				sb.WriteByte('\t')
+			}
+			sb.WriteString(EscapeCell(field))
+		}
+		sb.WriteByte('\n')
+	}
+	if m.Header != nil {
+		writeRow(m.Header)
+	}
+	for _, row := range m.Rows {
+		writeRow(row)
+	}
+	written, err := io.WriteString(out, sb.String())
+	return int64(written), err
+}
+
+// JoinKind defines which rows are kept by [Model.Join]
+type JoinKind uint
+
+const (
+	// JoinInner keeps only the rows whose key is found in both models
+	JoinInner JoinKind = iota
+	// JoinLeft keeps all the rows of the left model, leaving the fields of the right one empty when no match is found
+	JoinLeft
+)
+
+// Join combines the rows of the model with the ones of other, matching the colorless values of the leftCol and rightCol
+// key columns. The resulting rows contain all the left fields followed by the right ones, except for the right key.
+// A row matching multiple rows of the other model is repeated once for each match, preserving the original order
+func (m *Model) Join(other *Model, leftCol, rightCol int, kind JoinKind) *Model {
+	// Rows are padded, so that the fields of the right model are always aligned under their header
+	rightWidth := len(other.Header)
+	for _, row := range other.Rows {
+		rightWidth = max(rightWidth, len(row))
+	}
+	withoutKey := func(row []string) []string {
+		fields := make([]string, 0, rightWidth)
+		for c := range rightWidth {
+			if c == rightCol {
+				continue
+			}
+			if c < len(row) {
+				fields = append(fields, row[c])
+			} else {
+				fields = append(fields, "")
+			}
+		}
+		return fields
+	}
+	leftWidth := len(m.Header)
+	for _, row := range m.Rows {
+		leftWidth = max(leftWidth, len(row))
+	}
+	padded := func(row []string) []string {
+		return append(row[:len(row):len(row)], make([]string, leftWidth-len(row))...)
+	}
+
+	matches := make(map[string][]int)
+	for r, key := range other.column(rightCol) {
+		matches[key] = append(matches[key], r)
+	}
+
+	joined := &Model{Rows: make([][]string, 0, len(m.Rows))}
+	if m.Header != nil || other.Header != nil {
+		joined.Header = append(padded(m.Header), withoutKey(other.Header)...)
+	}
+	for r, key := range m.column(leftCol) {
+		for _, match := range matches[key] {
+			joined.Rows = append(joined.Rows, append(padded(m.Rows[r]), withoutKey(other.Rows[match])...))
+		}
+		if len(matches[key]) == 0 && kind == JoinLeft {
+			joined.Rows = append(joined.Rows, append(padded(m.Rows[r]), withoutKey(nil)...))
+		}
+	}
+	return joined
+}
@@ -0,0 +1,60 @@
+package TableWriter
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	users := &Model{
+		Header: []string{"ID", "Name"},
+		Rows:   [][]string{{"1", "Alice"}, {"2", "Bob"}, {"\x1b[1m3\x1b[0m", "Carol", "extra"}},
+	}
+	orders := &Model{
+		Header: []string{"Item", "User"},
+		Rows:   [][]string{{"pen", "1"}, {"ink", "3"}, {"cup", "1"}, {"box"}},
+	}
+	tests := []struct {
+		name string
+		kind JoinKind
+		want Model
+	}{
+		{
+			name: "inner",
+			kind: JoinInner,
+			want: Model{
+				Header: []string{"ID", "Name", "", "Item"},
+				Rows: [][]string{
+					{"1", "Alice", "", "pen"},
+					{"1", "Alice", "", "cup"},
+					{"\x1b[1m3\x1b[0m", "Carol", "extra", "ink"},
+				},
+			},
+		},
+		{
+			name: "left",
+			kind: JoinLeft,
+			want: Model{
+				Header: []string{"ID", "Name", "", "Item"},
+				Rows: [][]string{
+					{"1", "Alice", "", "pen"},
+					{"1", "Alice", "", "cup"},
+					{"2", "Bob", "", ""},
+					{"\x1b[1m3\x1b[0m", "Carol", "extra", "ink"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := users.Join(orders, 0, 1, tt.kind)
+			if !slices.Equal(got.Header, tt.want.Header) || !slices.EqualFunc(got.Rows, tt.want.Rows, slices.Equal) {
+				t.Errorf("Join() = %q, want %q", *got, tt.want)
+			}
+		})
+	}
+	// The joined models are left untouched
+	if want := []string{"2", "Bob"}; !slices.Equal(users.Rows[1], want) {
+		t.Errorf("Join() modified the left row into %q, want %q", users.Rows[1], want)
+	}
+}
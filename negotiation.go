@@ -0,0 +1,89 @@
+package TableWriter
+
+// LayoutProposal describes the layout chosen for a table that does not fit the terminal
+type LayoutProposal struct {
+	// Natural holds the width required by each column to display its content without truncation
+	Natural []int
+	// Proposed holds the width assigned to each column. Hidden columns have a zero width
+	Proposed []int
+	// Required is the total width needed to render the table without truncating any field, borders included
+	Required int
+	// Available is the terminal's width
+	Available int
+}
+
+// LayoutDecision is the application's answer to a [LayoutProposal]
+type LayoutDecision struct {
+	// Widths overrides the width of each column. Missing or non-positive entries keep the proposed widths, while
+	// widths larger than the columns' content are reduced to fit it
+	Widths []int
+	// Drop lists the indexes of the columns to remove from the table. The space they free up is shared among the
+	// remaining columns, unless Widths is specified
+	Drop []int
+}
+
+// LayoutNegotiator is invoked whenever the table cannot fit the terminal, giving the application the final say on
+// how the table is degraded
+type LayoutNegotiator func(proposal LayoutProposal) LayoutDecision
+
+// SetLayoutNegotiator registers the function deciding the final layout of tables that do not fit the terminal.
+// Passing nil restores the default behavior, which always accepts the proposed layout
+func (w *Writer) SetLayoutNegotiator(negotiator LayoutNegotiator) {
+	w.negotiator = negotiator
+}
+
+// WithLayoutNegotiator registers the function deciding the final layout of tables that do not fit the terminal.
+// See [Writer.SetLayoutNegotiator]
+func WithLayoutNegotiator(negotiator LayoutNegotiator) Option {
+	return func(w *Writer) {
+		w.SetLayoutNegotiator(negotiator)
+	}
+}
+
+// negotiateLayout submits the given budgets to the [LayoutNegotiator] when some column cannot fit, and returns the
+// budgets resulting from its decision
+func (w *Writer) negotiateLayout(budgets []int) []int {
+	if w.negotiator == nil {
+		return budgets
+	}
+	proposal := LayoutProposal{
+		Natural:   make([]int, len(w.columns)),
+		Proposed:  make([]int, len(w.columns)),
+		Required:  1,
+		Available: w.termCols,
+	}
+	overBudget := false
+	for c := range w.columns {
+		proposal.Natural[c] = w.columns[c].textWidth
+		if w.columns[c].hidden {
+			continue
+		}
+		proposal.Proposed[c] = min(w.columns[c].textWidth, budgets[c])
+		proposal.Required += w.columns[c].textWidth + w.leastPadding(c) + 1
+		overBudget = overBudget || w.columns[c].textWidth > budgets[c]
+	}
+	if !overBudget {
+		return budgets
+	}
+
+	decision := w.negotiator(proposal)
+	dropped := false
+	for _, c := range decision.Drop {
+		if c >= 0 && c < len(w.columns) && !w.columns[c].hidden {
+			w.columns[c].hidden = true
+			dropped = true
+			w.debug("column dropped", "col", c, "width", w.columns[c].textWidth, "budget", budgets[c],
+				"reason", "layout negotiator")
+			w.emit(ColumnDropped{Col: c})
+		}
+	}
+	if dropped {
+		budgets = w.columnBudgets()
+	}
+	for c, width := range decision.Widths {
+		if c < len(budgets) && width > 0 {
+			budgets[c] = width
+		}
+	}
+	return budgets
+}
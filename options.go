@@ -0,0 +1,350 @@
+package TableWriter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Option configures a [Writer] when it is created by [NewWriter]
+type Option func(*Writer)
+
+// WithFlags enables the given flags, in addition to the ones passed to [NewWriter]
+func WithFlags(flags uint) Option {
+	return func(w *Writer) {
+		w.flags |= flags
+	}
+}
+
+// WithColumnSpec configures the column at the given index. See [Writer.SetColumnSpec]
+func WithColumnSpec(col int, spec ColumnSpec) Option {
+	return func(w *Writer) {
+		w.SetColumnSpec(col, spec)
+	}
+}
+
+// WithDefaultColumnSpec configures all the columns without a specific configuration. See [Writer.SetDefaultColumnSpec]
+func WithDefaultColumnSpec(spec ColumnSpec) Option {
+	return func(w *Writer) {
+		w.SetDefaultColumnSpec(spec)
+	}
+}
+
+// WithMaxRowLines limits the number of lines each row can span over. See [Writer.SetMaxRowLines]
+func WithMaxRowLines(n int) Option {
+	return func(w *Writer) {
+		w.SetMaxRowLines(n)
+	}
+}
+
+// optionParsers maps the name of each setting accepted by [OptionsFromArgs] to the function building its [Option]
+var optionParsers = map[string]func(value string) (Option, error){
+	"align": func(value string) (Option, error) {
+		alignments := map[string]uint{"left": 0, "middle": AlignMiddle, "right": AlignRight}
+		flag, ok := alignments[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid alignment %q", value)
+		}
+		return func(w *Writer) {
+			w.flags = w.flags&^(AlignMiddle|AlignRight) | flag
+		}, nil
+	},
+	"truncate": func(value string) (Option, error) {
+		policies := map[string]TruncatePolicy{
+			"cut":    TruncateCut,
+			"middle": TruncateMiddle,
+			"wrap":   TruncateWrap,
+			"hide":   TruncateHide,
+			"never":  TruncateNever,
+		}
+		policy, ok := policies[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid truncation policy %q", value)
+		}
+		return func(w *Writer) {
+			w.defaultSpec.Truncate = policy
+		}, nil
+	},
+	"summary": func(value string) (Option, error) {
+		summaries := map[string]ColumnSummary{
+			"none":   SummaryNone,
+			"count":  SummaryCount,
+			"unique": SummaryUnique,
+		}
+		summary, ok := summaries[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid summary %q", value)
+		}
+		return func(w *Writer) {
+			w.defaultSpec.Summary = summary
+		}, nil
+	},
+	"anonymize": func(value string) (Option, error) {
+		modes := map[string]AnonymizeMode{"none": AnonymizeNone, "hash": AnonymizeHash, "pseudonym": AnonymizePseudonym}
+		mode, ok := modes[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid anonymization mode %q", value)
+		}
+		return func(w *Writer) {
+			w.defaultSpec.Anonymize = mode
+		}, nil
+	},
+	"annotate": func(value string) (Option, error) {
+		annotations := map[string]Annotation{"none": AnnotateNone, "rank": AnnotateRank, "percentile": AnnotatePercentile}
+		annotation, ok := annotations[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid annotation %q", value)
+		}
+		return func(w *Writer) {
+			w.defaultSpec.Annotate = annotation
+		}, nil
+	},
+	"sanitize": func(value string) (Option, error) {
+		actions := map[string]SanitizeAction{"keep": SanitizeKeep, "strip": SanitizeStrip, "replace": SanitizeReplace}
+		category, name, _ := strings.Cut(value, ":")
+		action, ok := actions[name]
+		if _, known := unicode.Categories[category]; !ok || !known {
+			return nil, fmt.Errorf("invalid sanitization action %q", value)
+		}
+		return WithSanitizeAction(category, action), nil
+	},
+	"table-align": func(value string) (Option, error) {
+		alignments := map[string]Alignment{"left": Left, "center": Center, "right": Right}
+		align, ok := alignments[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid table alignment %q", value)
+		}
+		return WithTableAlignment(align), nil
+	},
+	"frame": func(value string) (Option, error) {
+		frames := map[string]Frame{"default": FrameDefault, "double": FrameDouble, "shadow": FrameShadow}
+		frame, ok := frames[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid frame %q", value)
+		}
+		return WithFrame(frame), nil
+	},
+	"borders": func(value string) (Option, error) {
+		borders := map[string]Borders{
+			"all":          BordersAll,
+			"none":         BordersNone,
+			"outer":        BordersOuter,
+			"header":       BordersHeader,
+			"header-frame": BordersHeaderFrame,
+		}
+		b, ok := borders[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid borders %q", value)
+		}
+		return WithBorders(b), nil
+	},
+	"border-color": func(value string) (Option, error) {
+		if _, err := sgrSequence(value); err != nil {
+			return nil, err
+		}
+		return WithBorderColor(value), nil
+	},
+	"style": func(value string) (Option, error) {
+		styles := map[string]Style{
+			"default": StyleDefault,
+			"rounded": StyleRounded,
+			"double":  StyleDouble,
+			"heavy":   StyleHeavy,
+			"dotted":  StyleDotted,
+			"minimal": StyleMinimal,
+		}
+		style, ok := styles[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid style %q", value)
+		}
+		return WithStyle(style), nil
+	},
+	"output": func(value string) (Option, error) {
+		formats := map[string]OutputFormat{
+			"table":       FormatTable,
+			"html":        FormatHTML,
+			"csv":         FormatCSV,
+			"tsv":         FormatTSV,
+			"json":        FormatJSON,
+			"json-arrays": FormatJSONArrays,
+			"json-meta":   FormatJSONMetadata,
+			"rst":         FormatRST,
+			"asciidoc":    FormatAsciiDoc,
+			"jira":        FormatJira,
+		}
+		format, ok := formats[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid output format %q", value)
+		}
+		return WithOutputFormat(format), nil
+	},
+	"export-encoding": func(value string) (Option, error) {
+		encodings := map[string]ExportEncoding{
+			"utf-8":        EncodingUTF8,
+			"utf-8-bom":    EncodingUTF8BOM,
+			"utf-16le":     EncodingUTF16LE,
+			"latin1":       EncodingLatin1,
+			"windows-1252": EncodingWindows1252,
+		}
+		encoding, ok := encodings[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid export encoding %q", value)
+		}
+		return WithExportEncoding(encoding), nil
+	},
+	"line-ending": func(value string) (Option, error) {
+		endings := map[string]LineEnding{"lf": LineEndingLF, "crlf": LineEndingCRLF}
+		ending, ok := endings[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid line ending %q", value)
+		}
+		return WithLineEnding(ending), nil
+	},
+	"carriage-return": func(value string) (Option, error) {
+		policy, err := parseCarriageReturnPolicy(value)
+		if err != nil {
+			return nil, err
+		}
+		return WithCarriageReturnPolicy(policy), nil
+	},
+	"emoji-width": func(value string) (Option, error) {
+		policies := map[string]EmojiWidth{"auto": EmojiWidthAuto, "narrow": EmojiNarrow, "wide": EmojiWide}
+		policy, ok := policies[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid emoji width %q", value)
+		}
+		return WithEmojiWidth(policy), nil
+	},
+	"ambiguous-width": func(value string) (Option, error) {
+		policies := map[string]AmbiguousWidth{"auto": AmbiguousWidthAuto, "narrow": AmbiguousNarrow, "wide": AmbiguousWide}
+		policy, ok := policies[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid ambiguous width %q", value)
+		}
+		return WithAmbiguousWidth(policy), nil
+	},
+	"responsive": func(value string) (Option, error) {
+		return WithActiveResponsivePreset(value), nil
+	},
+	"max-buffer-size": func(value string) (Option, error) {
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maximum buffer size %q", value)
+		}
+		return WithMaxBufferSize(size), nil
+	},
+	"width": func(value string) (Option, error) {
+		cols, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid width %q", value)
+		}
+		return WithWidth(cols), nil
+	},
+	"height": func(value string) (Option, error) {
+		rows, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid height %q", value)
+		}
+		return WithHeight(rows), nil
+	},
+	"default-width": func(value string) (Option, error) {
+		cols, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default width %q", value)
+		}
+		return WithDefaultWidth(cols), nil
+	},
+	"binary-threshold": func(value string) (Option, error) {
+		threshold, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid binary threshold %q", value)
+		}
+		return WithBinaryThreshold(threshold), nil
+	},
+	"max-row-lines": func(value string) (Option, error) {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maximum number of lines per row %q", value)
+		}
+		return WithMaxRowLines(n), nil
+	},
+	"column-widths": func(value string) (Option, error) {
+		// Widths are separated by colons, since commas separate the settings
+		fields := strings.Split(value, ":")
+		widths := make([]int, len(fields))
+		for c, field := range fields {
+			width, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid column widths %q", value)
+			}
+			widths[c] = width
+		}
+		return WithColumnWidths(widths), nil
+	},
+	"strip-colours":        flagParser(StripColours),
+	"remove-least-pad":     flagParser(RemoveLeastPad),
+	"preserve-long-fields": flagParser(PreserveLongFields),
+	"ascii":                flagParser(AsciiTable),
+	"mark-wrapped":         flagParser(MarkWrappedLines),
+	"copy-friendly":        flagParser(CopyFriendly),
+	"drop-trailing-tab":    flagParser(DropTrailingTab),
+	"alt-screen":           flagParser(AlternateScreen),
+	"highlight-changes":    flagParser(HighlightChanges),
+	"align-numbers":        flagParser(AlignNumbers),
+	"keep-marks":           flagParser(PreserveCombiningMarks),
+	"legend":               flagParser(ShowLegend),
+	"markup":               flagParser(InlineMarkup),
+	"auto-strip-colours":   flagParser(AutoStripColours),
+	"tty-fallback":         flagParser(TTYFallback),
+	"compact":              flagParser(Compact),
+}
+
+// flagParser returns the parser of a boolean setting that enables or disables the given flag.
+// An empty value enables the flag
+func flagParser(flag uint) func(value string) (Option, error) {
+	return func(value string) (Option, error) {
+		enabled := true
+		if value != "" {
+			var err error
+			if enabled, err = strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("invalid boolean value %q", value)
+			}
+		}
+		return func(w *Writer) {
+			if enabled {
+				w.flags |= flag
+			} else {
+				w.flags &^= flag
+			}
+		}, nil
+	}
+}
+
+// OptionsFromArgs converts a list of "name=value" settings into the corresponding options, so that applications can
+// let their users configure the [Writer] with a single string, like "align=right,truncate=wrap".
+// Each argument can contain multiple comma-separated settings, boolean settings can omit their value and leading
+// dashes are ignored, so command line arguments such as "--ascii" are accepted as well.
+// The names match the options of the tablewriter command
+func OptionsFromArgs(args []string) ([]Option, error) {
+	opts := make([]Option, 0, len(args))
+	for _, arg := range args {
+		for _, setting := range strings.Split(arg, ",") {
+			setting = strings.TrimLeft(strings.TrimSpace(setting), "-")
+			if setting == "" {
+				continue
+			}
+			name, value, _ := strings.Cut(setting, "=")
+			parser, ok := optionParsers[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown option %q", name)
+			}
+			opt, err := parser(value)
+			if err != nil {
+				return nil, fmt.Errorf("option %q: %w", name, err)
+			}
+			opts = append(opts, opt)
+		}
+	}
+	return opts, nil
+}
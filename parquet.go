@@ -0,0 +1,249 @@
+package TableWriter
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Values of the Parquet enumerations used by [Writer.ExportParquet]. See https://github.com/apache/parquet-format
+const (
+	parquetBoolean   = 0
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetOptional      = 1
+	parquetUTF8          = 0
+	parquetPlain         = 0
+	parquetRLE           = 3
+	parquetUncompressed  = 0
+	parquetDataPage      = 0
+	parquetFormatVersion = 1
+)
+
+// Types of the fields encoded with the Thrift compact protocol
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes the Parquet metadata with the Thrift compact protocol
+type thriftWriter struct {
+	buf []byte
+	// fields holds the id of the last field written in each of the open structs, since ids are encoded as deltas
+	fields []int16
+}
+
+// field writes the header of the field with the given id and type
+func (t *thriftWriter) field(id int16, kind byte) {
+	last := &t.fields[len(t.fields)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		t.buf = append(t.buf, byte(delta)<<4|kind)
+	} else {
+		t.buf = binary.AppendVarint(append(t.buf, kind), int64(id))
+	}
+	*last = id
+}
+
+// i32 writes a 32-bit integer field, which also encodes the enumerations
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.buf = binary.AppendVarint(t.buf, int64(v))
+}
+
+// i64 writes a 64-bit integer field
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.buf = binary.AppendVarint(t.buf, v)
+}
+
+// binary writes a string field
+func (t *thriftWriter) binary(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.buf = append(binary.AppendUvarint(t.buf, uint64(len(s))), s...)
+}
+
+// list writes the header of a list field holding the given number of elements of the given type, which must follow
+func (t *thriftWriter) list(id int16, kind byte, size int) {
+	t.field(id, thriftList)
+	if size < 15 {
+		t.buf = append(t.buf, byte(size)<<4|kind)
+	} else {
+		t.buf = binary.AppendUvarint(append(t.buf, 0xf0|kind), uint64(size))
+	}
+}
+
+// begin opens a struct, either as the field with the given id or, when id is 0, as a list's element or the top level
+// one. Structs are closed by end
+func (t *thriftWriter) begin(id int16) {
+	if id != 0 {
+		t.field(id, thriftStruct)
+	}
+	t.fields = append(t.fields, 0)
+}
+
+// end closes the last open struct
+func (t *thriftWriter) end() {
+	t.buf = append(t.buf, 0)
+	t.fields = t.fields[:len(t.fields)-1]
+}
+
+// parquetColumn returns the physical type of the columns of the given type and the function appending their values
+// with the plain encoding, reporting false for the values that cannot be converted, which are stored as nulls.
+// Booleans are collected as bytes and packed at the end
+func parquetColumn(t ColumnType) (int32, func([]byte, string) ([]byte, bool)) {
+	switch t {
+	case TypeInteger:
+		return parquetInt64, func(b []byte, s string) ([]byte, bool) {
+			n, err := strconv.ParseInt(s, 10, 64)
+			return binary.LittleEndian.AppendUint64(b, uint64(n)), err == nil
+		}
+	case TypeFloat:
+		return parquetDouble, func(b []byte, s string) ([]byte, bool) {
+			n, err := strconv.ParseFloat(s, 64)
+			return binary.LittleEndian.AppendUint64(b, math.Float64bits(n)), err == nil
+		}
+	case TypeBool:
+		return parquetBoolean, func(b []byte, s string) ([]byte, bool) {
+			v, ok := parseBool(s)
+			if v {
+				return append(b, 1), ok
+			}
+			return append(b, 0), ok
+		}
+	default:
+		return parquetByteArray, func(b []byte, s string) ([]byte, bool) {
+			return append(binary.LittleEndian.AppendUint32(b, uint32(len(s))), s...), true
+		}
+	}
+}
+
+// packBits packs the given bits, one per byte, into bytes starting from the least significant bit, as done by the
+// plain encoding of booleans and by the bit-packed runs of definition levels
+func packBits(bits []byte) []byte {
+	packed := make([]byte, (len(bits)+7)/8)
+	for i, bit := range bits {
+		packed[i/8] |= bit << (i % 8)
+	}
+	return packed
+}
+
+// ExportParquet writes the buffered data, without consuming it, to out as a Parquet file, so that it can be loaded
+// straight into data-science tools like pandas or DuckDB. Columns are named after the header's fields and typed
+// according to [Writer.InferSchema]: integers, floats and booleans are stored as such, while the other columns hold
+// UTF-8 strings. Empty fields are stored as nulls. The file holds a single uncompressed row group
+func (w *Writer) ExportParquet(out io.Writer) error {
+	m := w.exportModel()
+	schema := w.inferSchema(m)
+	names := columnNames(schema)
+
+	file := []byte("PAR1")
+	meta := &thriftWriter{}
+	meta.begin(0)
+	meta.i32(1, parquetFormatVersion)
+	meta.list(2, thriftStruct, len(schema)+1)
+	// The root of the schema groups the table's columns
+	meta.begin(0)
+	meta.binary(4, "schema")
+	meta.i32(5, int32(len(schema)))
+	meta.end()
+	types := make([]int32, len(schema))
+	for c := range schema {
+		types[c], _ = parquetColumn(schema[c].Type)
+		meta.begin(0)
+		meta.i32(1, types[c])
+		meta.i32(3, parquetOptional)
+		meta.binary(4, names[c])
+		if types[c] == parquetByteArray {
+			meta.i32(6, parquetUTF8)
+			// The logical type is a union, whose STRING member is an empty struct
+			meta.begin(10)
+			meta.begin(1)
+			meta.end()
+			meta.end()
+		}
+		meta.end()
+	}
+	meta.i64(3, int64(len(m.Rows)))
+
+	// Each column is stored as a single data page, whose definition levels tell the nulls apart
+	type chunk struct {
+		offset, size int64
+	}
+	chunks := make([]chunk, len(schema))
+	for c := range schema {
+		_, appendValue := parquetColumn(schema[c].Type)
+		levels := make([]byte, len(m.Rows))
+		var values []byte
+		for r, row := range m.Rows {
+			value := stripEscapeCodes(fieldAt(row, c))
+			if value == "" {
+				continue
+			}
+			if appended, ok := appendValue(values, value); ok {
+				values, levels[r] = appended, 1
+			}
+		}
+		if types[c] == parquetBoolean {
+			values = packBits(values)
+		}
+		// Definition levels are a single run of bit-packed groups of 8 values, prefixed by its length
+		run := binary.AppendUvarint(nil, uint64((len(levels)+7)/8)<<1|1)
+		run = append(run, packBits(levels)...)
+		data := append(binary.LittleEndian.AppendUint32(nil, uint32(len(run))), run...)
+		data = append(data, values...)
+
+		page := &thriftWriter{}
+		page.begin(0)
+		page.i32(1, parquetDataPage)
+		page.i32(2, int32(len(data)))
+		page.i32(3, int32(len(data)))
+		page.begin(5)
+		page.i32(1, int32(len(m.Rows)))
+		page.i32(2, parquetPlain)
+		page.i32(3, parquetRLE)
+		page.i32(4, parquetRLE)
+		page.end()
+		page.end()
+
+		chunks[c] = chunk{int64(len(file)), int64(len(page.buf) + len(data))}
+		file = append(append(file, page.buf...), data...)
+	}
+
+	meta.list(4, thriftStruct, 1)
+	meta.begin(0)
+	meta.list(1, thriftStruct, len(schema))
+	total := int64(0)
+	for c := range schema {
+		meta.begin(0)
+		meta.i64(2, chunks[c].offset)
+		meta.begin(3)
+		meta.i32(1, types[c])
+		meta.list(2, thriftI32, 2)
+		meta.buf = binary.AppendVarint(binary.AppendVarint(meta.buf, parquetPlain), parquetRLE)
+		meta.list(3, thriftBinary, 1)
+		meta.buf = append(binary.AppendUvarint(meta.buf, uint64(len(names[c]))), names[c]...)
+		meta.i32(4, parquetUncompressed)
+		meta.i64(5, int64(len(m.Rows)))
+		meta.i64(6, chunks[c].size)
+		meta.i64(7, chunks[c].size)
+		meta.i64(9, chunks[c].offset)
+		meta.end()
+		meta.end()
+		total += chunks[c].size
+	}
+	meta.i64(2, total)
+	meta.i64(3, int64(len(m.Rows)))
+	meta.end()
+	meta.end()
+
+	file = append(file, meta.buf...)
+	file = binary.LittleEndian.AppendUint32(file, uint32(len(meta.buf)))
+	_, err := out.Write(append(file, "PAR1"...))
+	return err
+}
@@ -0,0 +1,171 @@
+package TableWriter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// thriftFields is a struct decoded from the Thrift compact protocol, holding its fields by id
+type thriftFields map[int16]any
+
+// readThriftValue decodes a value of the given type from the Thrift compact protocol.
+// Only the types written by thriftWriter are supported
+func readThriftValue(r *bytes.Reader, kind byte) (any, error) {
+	switch kind {
+	case thriftI32, thriftI64:
+		return binary.ReadVarint(r)
+	case thriftBinary:
+		size, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, size)
+		_, err = io.ReadFull(r, b)
+		return string(b), err
+	case thriftList:
+		header, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		size := uint64(header >> 4)
+		if size == 15 {
+			if size, err = binary.ReadUvarint(r); err != nil {
+				return nil, err
+			}
+		}
+		list := make([]any, size)
+		for i := range list {
+			if list[i], err = readThriftValue(r, header&0x0f); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case thriftStruct:
+		s := make(thriftFields)
+		id := int16(0)
+		for {
+			header, err := r.ReadByte()
+			if err != nil || header == 0 {
+				return s, err
+			}
+			if delta := int16(header >> 4); delta != 0 {
+				id += delta
+			} else {
+				n, err := binary.ReadVarint(r)
+				if err != nil {
+					return nil, err
+				}
+				id = int16(n)
+			}
+			if s[id], err = readThriftValue(r, header&0x0f); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported Thrift type %d", kind)
+	}
+}
+
+// readParquet decodes the header and the rows of a Parquet file written by [Writer.ExportParquet], formatting the
+// values as strings. Nulls are returned as empty strings
+func readParquet(t *testing.T, file []byte) ([]string, [][]string) {
+	t.Helper()
+	if !bytes.HasPrefix(file, []byte("PAR1")) || !bytes.HasSuffix(file, []byte("PAR1")) {
+		t.Fatal("missing Parquet magic numbers")
+	}
+	size := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	decoded, err := readThriftValue(bytes.NewReader(file[len(file)-8-size:len(file)-8]), thriftStruct)
+	if err != nil {
+		t.Fatalf("invalid file metadata: %v", err)
+	}
+	meta := decoded.(thriftFields)
+	rows := make([][]string, meta[3].(int64))
+	header := make([]string, 0)
+	for _, element := range meta[2].([]any)[1:] {
+		header = append(header, element.(thriftFields)[4].(string))
+	}
+	for r := range rows {
+		rows[r] = make([]string, len(header))
+	}
+
+	columns := meta[4].([]any)[0].(thriftFields)[1].([]any)
+	for c, column := range columns {
+		chunk := column.(thriftFields)[3].(thriftFields)
+		r := bytes.NewReader(file[chunk[9].(int64):])
+		decoded, err := readThriftValue(r, thriftStruct)
+		if err != nil {
+			t.Fatalf("invalid page header: %v", err)
+		}
+		data := make([]byte, decoded.(thriftFields)[3].(int64))
+		if _, err = io.ReadFull(r, data); err != nil {
+			t.Fatalf("truncated page: %v", err)
+		}
+		levels := data[4 : 4+binary.LittleEndian.Uint32(data)]
+		values := data[4+len(levels):]
+		_, n := binary.Uvarint(levels)
+		levels = levels[n:]
+
+		defined := 0
+		for row := range rows {
+			if levels[row/8]>>(row%8)&1 == 0 {
+				continue
+			}
+			switch chunk[1].(int64) {
+			case parquetInt64:
+				rows[row][c] = strconv.FormatInt(int64(binary.LittleEndian.Uint64(values)), 10)
+				values = values[8:]
+			case parquetDouble:
+				rows[row][c] = strconv.FormatFloat(math.Float64frombits(binary.LittleEndian.Uint64(values)), 'g', -1, 64)
+				values = values[8:]
+			case parquetBoolean:
+				rows[row][c] = strconv.FormatBool(values[defined/8]>>(defined%8)&1 == 1)
+			case parquetByteArray:
+				length := binary.LittleEndian.Uint32(values)
+				rows[row][c] = string(values[4 : 4+length])
+				values = values[4+length:]
+			}
+			defined++
+		}
+	}
+	return header, rows
+}
+
+func TestExportParquetRoundTrip(t *testing.T) {
+	w := NewWriter(io.Discard, 0)
+	input := "ID\tName\tScore\tActive\tName\n" +
+		"1\t\x1b[1mAlice\x1b[0m\t1.5\ttrue\tx\n" +
+		"2\t\t-2\tfalse\n" +
+		"3\tCarol\t\tTRUE\ty\n"
+	for i := 4; i <= 20; i++ {
+		input += fmt.Sprintf("%d\tuser%d\t%d.25\tfalse\tz\n", i, i, i)
+	}
+	if _, err := io.WriteString(w, input); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := w.ExportParquet(&buf); err != nil {
+		t.Fatalf("ExportParquet() error = %v", err)
+	}
+	header, rows := readParquet(t, buf.Bytes())
+
+	if want := []string{"ID", "Name", "Score", "Active", "Name_2"}; !slices.Equal(header, want) {
+		t.Errorf("header = %q, want %q", header, want)
+	}
+	want := [][]string{
+		{"1", "Alice", "1.5", "true", "x"},
+		{"2", "", "-2", "false", ""},
+		{"3", "Carol", "", "true", "y"},
+	}
+	for i := 4; i <= 20; i++ {
+		want = append(want, []string{strconv.Itoa(i), "user" + strconv.Itoa(i), strconv.Itoa(i) + ".25", "false", "z"})
+	}
+	if !slices.EqualFunc(rows, want, slices.Equal) {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+}
@@ -0,0 +1,59 @@
+package TableWriter
+
+// PercentSpec formats a column of percentages (e.g. SLO compliance or resource utilization), coloring its values by
+// their deviation from a target range and drawing a compact gauge next to them. See [ColumnSpec.Percent]
+type PercentSpec struct {
+	// Low and High delimit the target range of the values, which are colored in green when they fall within it.
+	// Set both to the same value for a single target
+	Low, High float64
+	// Tolerance is the deviation from the target range still colored in yellow, rather than in red
+	Tolerance float64
+	// Gauge is the width of the gauge drawn after each value, filled in proportion to it (100% fills it).
+	// Zero draws no gauge
+	Gauge int
+}
+
+// color returns the color of the given value, according to its deviation from the target range
+func (p *PercentSpec) color(value float64) string {
+	deviation := max(p.Low-value, value-p.High, 0)
+	switch {
+	case deviation == 0:
+		return colorGreen
+	case deviation <= p.Tolerance:
+		return colorYellow
+	default:
+		return colorRed
+	}
+}
+
+// formatPercentages colors the numeric data fields of the columns configured with a [PercentSpec] according to their
+// deviation from the target range, and appends their gauges. Non-numeric fields are left untouched
+func (w *Writer) formatPercentages() {
+	for r, cells := range w.rows {
+		if r == 0 || w.isTemplateRow(cells) {
+			continue
+		}
+		for c := range cells {
+			spec := w.columnSpec(c).Percent
+			if spec == nil {
+				continue
+			}
+			value, ok := fieldNumber(cells[c].plain)
+			if !ok {
+				continue
+			}
+			color := spec.color(value)
+			if w.flags&StripColours == 0 {
+				cells[c].text = color + cells[c].text + colorReset
+			}
+			if spec.Gauge > 0 {
+				gauge := "[" + w.bar(max(value, 0)/100, spec.Gauge) + "]"
+				cells[c].plain += " " + gauge
+				if w.flags&StripColours == 0 {
+					gauge = color + gauge + colorReset
+				}
+				cells[c].text += " " + gauge
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package TableWriter
+
+import (
+	"os"
+	"path"
+)
+
+// TerminalProfile holds the adjustments applied to the tables rendered on a specific terminal, in order to work
+// around its rendering quirks
+type TerminalProfile struct {
+	// Glyphs replaces the characters used to draw the table's borders and markers with other ones, e.g. "┼" with "+"
+	Glyphs map[string]string
+	// EmojiWidth overrides the detected width of emoji, unless it is [EmojiWidthAuto].
+	// It is ignored when the width is set explicitly by [Writer.SetEmojiWidth]
+	EmojiWidth EmojiWidth
+}
+
+// terminalProfile associates a [TerminalProfile] with the pattern matching the terminals it applies to
+type terminalProfile struct {
+	pattern string
+	profile TerminalProfile
+}
+
+// SetTerminalProfile registers a profile applied automatically when the Writer runs on the given terminal, which is
+// matched against the TERM (e.g. "linux" or "xterm-*") and TERM_PROGRAM (e.g. "Apple_Terminal") environment
+// variables, using the syntax of [path.Match]. When multiple profiles match, the ones registered later take
+// precedence
+func (w *Writer) SetTerminalProfile(terminal string, profile TerminalProfile) {
+	w.profiles = append(w.profiles, terminalProfile{pattern: terminal, profile: profile})
+	w.SetEmojiWidth(w.emojiPolicy)
+	w.initDividers()
+}
+
+// WithTerminalProfile registers a profile applied automatically when the Writer runs on the given terminal.
+// See [Writer.SetTerminalProfile]
+func WithTerminalProfile(terminal string, profile TerminalProfile) Option {
+	return func(w *Writer) {
+		w.SetTerminalProfile(terminal, profile)
+	}
+}
+
+// detectedProfiles returns the registered profiles matching the current terminal, in order of precedence
+func (w *Writer) detectedProfiles() []TerminalProfile {
+	terminals := []string{os.Getenv("TERM"), os.Getenv("TERM_PROGRAM")}
+	profiles := make([]TerminalProfile, 0)
+	for _, p := range w.profiles {
+		for _, terminal := range terminals {
+			if matched, _ := path.Match(p.pattern, terminal); matched && terminal != "" {
+				profiles = append(profiles, p.profile)
+				break
+			}
+		}
+	}
+	return profiles
+}
+
+// glyph returns the character drawn in place of the given one on the current terminal
+func (w *Writer) glyph(s string) string {
+	if len(w.profiles) == 0 {
+		return s
+	}
+	profiles := w.detectedProfiles()
+	for i := len(profiles) - 1; i >= 0; i-- {
+		if replacement, ok := profiles[i].Glyphs[s]; ok {
+			return replacement
+		}
+	}
+	return s
+}
+
+// overrideGlyphs replaces the dividers overridden by the profiles of the current terminal
+func (w *Writer) overrideGlyphs() {
+	for _, divider := range []*string{
+		&w.divider.HLine, &w.divider.VLine, &w.divider.OuterHLine, &w.divider.OuterVLine, &w.divider.TL,
+		&w.divider.TR, &w.divider.BL, &w.divider.BR, &w.divider.Cross, &w.divider.TUp, &w.divider.TDown,
+		&w.divider.TRight, &w.divider.TLeft, &w.divider.VLeft, &w.divider.VRight,
+	} {
+		*divider = w.glyph(*divider)
+	}
+}
@@ -0,0 +1,24 @@
+package TableWriter
+
+import "time"
+
+// SetMinFlushInterval sets the minimum time between two renders. Calls to [Writer.Flush] occurring earlier are
+// coalesced: the data stays buffered and is rendered by the first flush after the interval has elapsed.
+// This prevents producers flushing after every row from saturating slow terminals or SSH links.
+// Since coalesced data is only rendered by a later flush, producers should end with [Writer.EndTable] or
+// [Writer.Close], which always render. Zero disables the limit
+func (w *Writer) SetMinFlushInterval(interval time.Duration) {
+	w.minInterval = max(interval, 0)
+}
+
+// WithMinFlushInterval sets the minimum time between two renders. See [Writer.SetMinFlushInterval]
+func WithMinFlushInterval(interval time.Duration) Option {
+	return func(w *Writer) {
+		w.SetMinFlushInterval(interval)
+	}
+}
+
+// isRateLimited reports whether the current flush must be coalesced with the next one
+func (w *Writer) isRateLimited() bool {
+	return w.minInterval > 0 && !w.lastRender.IsZero() && time.Since(w.lastRender) < w.minInterval
+}
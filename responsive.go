@@ -0,0 +1,38 @@
+package TableWriter
+
+// ResponsivePreset defines the minimum terminal width required to display each column, indexed by column.
+// Columns that are not listed are always displayed
+type ResponsivePreset map[int]int
+
+// DefineResponsivePreset registers a named [ResponsivePreset] (e.g. "compact", "normal" or "wide"), so that tools
+// can offer consistent responsive tables selectable with a single option. Defining an existing preset replaces it
+func (w *Writer) DefineResponsivePreset(name string, preset ResponsivePreset) {
+	w.presets[name] = preset
+}
+
+// WithResponsivePreset registers a named [ResponsivePreset]. See [Writer.DefineResponsivePreset]
+func WithResponsivePreset(name string, preset ResponsivePreset) Option {
+	return func(w *Writer) {
+		w.DefineResponsivePreset(name, preset)
+	}
+}
+
+// UseResponsivePreset selects the [ResponsivePreset] applied by the next flushes, which can be changed at runtime.
+// Unknown presets and the empty name display all the columns
+func (w *Writer) UseResponsivePreset(name string) {
+	w.preset = name
+}
+
+// WithActiveResponsivePreset selects the [ResponsivePreset] applied by the flushes. See [Writer.UseResponsivePreset]
+func WithActiveResponsivePreset(name string) Option {
+	return func(w *Writer) {
+		w.UseResponsivePreset(name)
+	}
+}
+
+// isResponsiveHidden reports whether the column at the given index is hidden by the selected [ResponsivePreset],
+// because the terminal is too narrow. Nothing is hidden when the terminal's width is unknown
+func (w *Writer) isResponsiveHidden(c int) bool {
+	minWidth, ok := w.presets[w.preset][c]
+	return ok && w.termCols > 0 && w.termCols < minWidth
+}
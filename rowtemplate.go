@@ -0,0 +1,151 @@
+package TableWriter
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+// rowKind prefixes the values of the rows added by [Writer.AppendTemplateRow], identifying their template
+type rowKind string
+
+// TemplateRow holds the data available to the templates rendering special rows. See [Writer.SetRowTemplate]
+type TemplateRow struct {
+	// Kind is the kind of the row, which selected its template
+	Kind string
+	// Fields are the row's colorless fields, one for each of the table's columns
+	Fields []string
+	// Widths are the widths of the table's visible columns
+	Widths []int
+	// Width is the width available to the row, between the table's outer borders
+	Width int
+}
+
+// rowTemplateFuncs are the functions available to the templates rendering special rows, in addition to the
+// predefined ones of [text/template]
+func (w *Writer) rowTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		// repeat repeats the given string until it fills the given width
+		"repeat": func(s string, width int) string {
+			if w.stringWidth(s) == 0 || width <= 0 {
+				return ""
+			}
+			return w.sliceVisible(strings.Repeat(s, width/w.stringWidth(s)+1), 0, width)
+		},
+		// center centers the given text within the given width, filling the remaining space with the given string
+		"center": func(text string, width int, fill string) string {
+			space := max(width-w.stringWidth(text), 0)
+			if w.stringWidth(fill) == 0 {
+				fill = " "
+			}
+			left := strings.Repeat(fill, space/2/w.stringWidth(fill))
+			right := strings.Repeat(fill, (space-w.stringWidth(left))/w.stringWidth(fill))
+			return left + text + right
+		},
+		// width returns the number of terminal cells required to display the given text
+		"width": func(s string) int {
+			return w.stringWidth(stripEscapeCodes(s))
+		},
+	}
+}
+
+// SetRowTemplate registers the [text/template] rendering the rows of the given kind, added by
+// [Writer.AppendTemplateRow], in place of their fields. The template is executed with a [TemplateRow] and renders the
+// content of the row's physical lines between the table's outer borders, e.g. separators with text or banners.
+// Lines are measured as any other field, then padded or cut to fit the table.
+// Besides the predefined functions, templates can use repeat (e.g. {{repeat "─" .Width}}), center (e.g.
+// {{center "Totals" .Width "·"}}) and width, which measures a text
+func (w *Writer) SetRowTemplate(kind string, text string) error {
+	tmpl, err := template.New(kind).Funcs(w.rowTemplateFuncs()).Parse(text)
+	if err != nil {
+		return err
+	}
+	w.rowTemplates[kind] = tmpl
+	return nil
+}
+
+// WithRowTemplate registers the template rendering the rows of the given kind.
+// Invalid templates are ignored. See [Writer.SetRowTemplate]
+func WithRowTemplate(kind string, text string) Option {
+	return func(w *Writer) {
+		_ = w.SetRowTemplate(kind, text)
+	}
+}
+
+// AppendTemplateRow adds a row rendered by the template registered for the given kind, after the data written so
+// far. Fields are evaluated as by [Writer.AppendRow], while the rendered lines are measured and fitted to the table's
+// width, without affecting the columns' ones. Rows whose kind has no template are rendered as regular rows
+func (w *Writer) AppendTemplateRow(kind string, fields ...any) error {
+	return w.AppendRow(append([]any{rowKind(kind)}, fields...)...)
+}
+
+// templateKind returns the kind of the row added by [Writer.AppendTemplateRow] with the given values, or an empty
+// string for any other row
+func (w *Writer) templateKind(values []any) string {
+	if len(values) == 0 {
+		return ""
+	}
+	kind, _ := values[0].(rowKind)
+	return string(kind)
+}
+
+// isTemplateRow reports whether the given row is rendered by a template
+func (w *Writer) isTemplateRow(cells []cell) bool {
+	if len(cells) == 0 || cells[0].kind == "" {
+		return false
+	}
+	_, ok := w.rowTemplates[cells[0].kind]
+	return ok
+}
+
+// spanTemplateRows extends the rows rendered by templates over all the table's columns, with empty fields, so that
+// they span the whole table. Exceeding fields are dropped
+func (w *Writer) spanTemplateRows() {
+	for r, cells := range w.rows {
+		if !w.isTemplateRow(cells) {
+			continue
+		}
+		spanned := make([]cell, len(w.columns))
+		copy(spanned, cells)
+		for c := range spanned {
+			spanned[c].kind = cells[0].kind
+		}
+		w.rows[r] = spanned
+	}
+}
+
+// renderTemplateRow renders the physical lines of the given row through the template registered for its kind.
+// It reports false when the row has no template or when the template fails, so that the row is rendered as usual
+func (w *Writer) renderTemplateRow(cells []cell, visible []int) ([]byte, bool) {
+	if !w.isTemplateRow(cells) {
+		return nil, false
+	}
+	tmpl := w.rowTemplates[cells[0].kind]
+
+	data := TemplateRow{Kind: cells[0].kind, Fields: make([]string, len(cells)), Widths: make([]int, len(visible))}
+	for c := range cells {
+		data.Fields[c] = cells[c].plain
+	}
+	for i, c := range visible {
+		data.Widths[i] = w.columns[c].textWidth
+		padding, _, _ := w.getPadding(c, w.columns[c].textWidth)
+		data.Width += w.columns[c].textWidth + padding + 1
+	}
+	// The last column's right border is the table's outer one
+	data.Width = max(data.Width-1, 0)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		w.debug("row template failed", "kind", data.Kind, "error", err)
+		return nil, false
+	}
+	rowBuffer := make([]byte, 0)
+	for line := range strings.Lines(strings.TrimSuffix(buf.String(), "\n")) {
+		line = w.sliceVisible(strings.TrimSuffix(line, "\n"), 0, data.Width)
+		line += strings.Repeat(" ", data.Width-w.stringWidth(stripEscapeCodes(line)))
+		line = closeEscapes([]string{line})[0]
+		border := w.colorBorder(w.divider.OuterVLine)
+		rowBuffer = append(append(append(append(rowBuffer, border...), line...), border...), '\n')
+	}
+	return rowBuffer, true
+}
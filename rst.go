@@ -0,0 +1,56 @@
+package TableWriter
+
+import "strings"
+
+// renderRST renders the parsed rows as a reStructuredText grid table, whose first row is the header.
+// Fields containing line breaks span multiple lines of their row, while all the rows have the same number of columns
+func (w *Writer) renderRST() []byte {
+	rows := w.plainRows()
+	if len(rows) == 0 {
+		return nil
+	}
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	widths := make([]int, columns)
+	for _, row := range rows {
+		for c, field := range row {
+			widths[c] = max(widths[c], w.fieldWidth(field))
+		}
+	}
+
+	border := func(fill string) string {
+		var sb strings.Builder
+		for _, width := range widths {
+			sb.WriteString("+" + strings.Repeat(fill, width+2))
+		}
+		return sb.String() + "+\n"
+	}
+	var sb strings.Builder
+	sb.WriteString(border("-"))
+	for r, row := range rows {
+		lines := make([][]string, columns)
+		height := 1
+		for c := range columns {
+			lines[c] = strings.Split(fieldAt(row, c), "\n")
+			height = max(height, len(lines[c]))
+		}
+		for l := range height {
+			for c := range columns {
+				line := ""
+				if l < len(lines[c]) {
+					line = lines[c][l]
+				}
+				sb.WriteString("| " + line + strings.Repeat(" ", widths[c]-w.stringWidth(line)) + " ")
+			}
+			sb.WriteString("|\n")
+		}
+		if r == 0 {
+			sb.WriteString(border("="))
+		} else {
+			sb.WriteString(border("-"))
+		}
+	}
+	return []byte(sb.String())
+}
@@ -0,0 +1,141 @@
+package TableWriter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SanitizeAction defines how the characters of a Unicode category are processed when the buffered data is cleaned
+type SanitizeAction uint
+
+const (
+	// SanitizeKeep preserves the characters
+	SanitizeKeep SanitizeAction = iota
+	// SanitizeStrip removes the characters
+	SanitizeStrip
+	// SanitizeReplace replaces the characters with a visible placeholder: separators (Z) become regular spaces, while
+	// any other character becomes the replacement character (�)
+	SanitizeReplace
+)
+
+// defaultSanitizePolicy lists the Unicode categories of the invisible characters that cause misalignment, which are
+// removed unless configured otherwise:
+// Cf: format character (zero width joiner, LTR/RTL symbols)
+// Cc: control character (null, carriage return other than \n)
+// Mn: non-spacing characters (Accents or symbols that take no space), unless [PreserveCombiningMarks] is set
+// Zs: space separator (non-breaking space: U+00A0 etc.)
+// Zl, Zp: line and paragraph separators
+var defaultSanitizePolicy = map[string]SanitizeAction{
+	"Cf": SanitizeStrip,
+	"Cc": SanitizeStrip,
+	"Mn": SanitizeStrip,
+	"Zs": SanitizeStrip,
+	"Zl": SanitizeStrip,
+	"Zp": SanitizeStrip,
+}
+
+// SetSanitizeAction defines how the characters of the given Unicode category (e.g. "Zs" or "Cf", or a major
+// category such as "C") are processed, overriding the default policy. Specific categories take precedence over the
+// major ones. The characters required to lay out the table (spaces, tabs, line breaks and ANSI escape codes) are
+// always preserved
+func (w *Writer) SetSanitizeAction(category string, action SanitizeAction) error {
+	if _, ok := unicode.Categories[category]; !ok {
+		return fmt.Errorf("unknown Unicode category %q", category)
+	}
+	w.sanitizePolicy[category] = action
+	return nil
+}
+
+// WithSanitizeAction defines how the characters of the given Unicode category are processed.
+// Unknown categories are ignored. See [Writer.SetSanitizeAction]
+func WithSanitizeAction(category string, action SanitizeAction) Option {
+	return func(w *Writer) {
+		_ = w.SetSanitizeAction(category, action)
+	}
+}
+
+// SetSanitizer replaces the category-based policy with the given mapping, which is applied to each character of the
+// buffered data as in [strings.Map]: characters mapped to a negative value are removed. The characters required to
+// lay out the table are never passed to it. A nil mapping restores the policy
+func (w *Writer) SetSanitizer(mapping func(rune) rune) {
+	w.sanitizer = mapping
+}
+
+// WithSanitizer replaces the category-based policy with the given mapping. See [Writer.SetSanitizer]
+func WithSanitizer(mapping func(rune) rune) Option {
+	return func(w *Writer) {
+		w.SetSanitizer(mapping)
+	}
+}
+
+// sanitizeAction returns the action applied to the given character, according to the configured policy
+func (w *Writer) sanitizeAction(r rune) SanitizeAction {
+	// Specific categories are looked up before the major ones
+	for _, specific := range []bool{true, false} {
+		for category, action := range w.sanitizePolicy {
+			if (len(category) > 1) == specific && unicode.Is(unicode.Categories[category], r) {
+				return action
+			}
+		}
+	}
+	if unicode.Is(unicode.Mn, r) && w.flags&PreserveCombiningMarks != 0 {
+		return SanitizeKeep
+	}
+	for category, action := range defaultSanitizePolicy {
+		if unicode.Is(unicode.Categories[category], r) {
+			return action
+		}
+	}
+
+	// Any uncaught space character is removed to avoid alignment problems
+	if unicode.IsSpace(r) {
+		return SanitizeStrip
+	}
+	return SanitizeKeep
+}
+
+// cleanInvisibleChars processes the invisible characters of the given data according to the sanitization policy.
+// This preserve \t, \n, and the ANSI escape character (\x1b)
+func (w *Writer) cleanInvisibleChars(s string) string {
+	// Escape sequences are preserved as they are, since hyperlinks can be terminated by BEL
+	var sb strings.Builder
+	for segment, escape := range escapeSegments(s) {
+		if escape {
+			sb.WriteString(segment)
+		} else {
+			sb.WriteString(w.sanitize(segment))
+		}
+	}
+	return sb.String()
+}
+
+// sanitize processes the invisible characters of the given data, which contains no escape sequences
+func (w *Writer) sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		// Do nothing with characters that are needed by the Writer to correctly format the output
+		if r == ' ' || r == '\x1b' || r == '\t' || r == '\n' {
+			return r
+		}
+
+		// Joiners, variation selectors and emoji tags are part of grapheme clusters, which are measured as a whole
+		if r == zeroWidthJoiner || (r >= 0xFE00 && r <= 0xFE0F) || (r >= 0xE0020 && r <= 0xE007F) {
+			return r
+		}
+
+		if w.sanitizer != nil {
+			return w.sanitizer(r)
+		}
+		switch w.sanitizeAction(r) {
+		case SanitizeStrip:
+			return -1
+		case SanitizeReplace:
+			if unicode.In(r, unicode.Zs, unicode.Zl, unicode.Zp) {
+				return ' '
+			}
+			return unicode.ReplacementChar
+		default:
+			return r
+		}
+	}, s)
+}
@@ -0,0 +1,212 @@
+package TableWriter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnType is the type of the values held by a column, as detected by [Writer.InferSchema]
+type ColumnType uint
+
+const (
+	// TypeUnknown is reported for columns without any value
+	TypeUnknown ColumnType = iota
+	// TypeString is reported for columns holding values of different or textual types
+	TypeString
+	// TypeInteger is reported for columns holding integers only
+	TypeInteger
+	// TypeFloat is reported for columns holding finite numbers only, at least one of which is not an integer
+	TypeFloat
+	// TypeBool is reported for columns holding the literal "true" and "false" only, in any case
+	TypeBool
+	// TypeTime is reported for columns holding dates or RFC 3339 timestamps only
+	TypeTime
+)
+
+// String returns the name of the type
+func (t ColumnType) String() string {
+	switch t {
+	case TypeUnknown:
+		return "unknown"
+	case TypeString:
+		return "string"
+	case TypeInteger:
+		return "integer"
+	case TypeFloat:
+		return "float"
+	case TypeBool:
+		return "bool"
+	case TypeTime:
+		return "time"
+	default:
+		return "invalid"
+	}
+}
+
+// MarshalText implements [encoding.TextMarshaler], so that types are exported by their name
+func (t ColumnType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// ColumnSchema describes the values held by a column of the buffered data
+type ColumnSchema struct {
+	// Name is the column's header
+	Name string `json:"name"`
+	// Type is the type detected from the column's values
+	Type ColumnType `json:"type"`
+	// MaxWidth is the width of the column's widest value, header excluded
+	MaxWidth int `json:"width"`
+	// Nulls is the number of data rows whose field is empty or missing
+	Nulls int `json:"nulls"`
+	// Unique is the number of distinct non-empty values
+	Unique int `json:"unique"`
+	// Aggregates summarizes the values of numeric columns, while it is nil for the other ones
+	Aggregates *ColumnAggregates `json:"aggregates,omitempty"`
+}
+
+// ColumnAggregates summarizes the values held by a numeric column
+type ColumnAggregates struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Sum   float64 `json:"sum"`
+	Mean  float64 `json:"mean"`
+}
+
+// TableMetadata describes the buffered table, so that the consumers of exported data get the schema information
+// along with the raw rows. It can be encoded with [encoding/json]
+type TableMetadata struct {
+	// Rows is the number of data rows, header excluded
+	Rows int `json:"rows"`
+	// Columns describes each of the table's columns
+	Columns []ColumnSchema `json:"columns"`
+}
+
+// DescribeTable scans the data buffered so far, without consuming it, and returns its [TableMetadata]
+func (w *Writer) DescribeTable() TableMetadata {
+	return TableMetadata{Rows: len(w.Model().Rows), Columns: w.InferSchema()}
+}
+
+// InferSchema scans the data buffered so far, without consuming it, and describes each of its columns.
+// Callers can use it to configure the alignment and the formatting of the columns, or to validate their input
+func (w *Writer) InferSchema() []ColumnSchema {
+	return w.inferSchema(w.Model())
+}
+
+// inferSchema describes each of the columns of the given model
+func (w *Writer) inferSchema(m *Model) []ColumnSchema {
+	columns := len(m.Header)
+	for _, row := range m.Rows {
+		columns = max(columns, len(row))
+	}
+	schema := make([]ColumnSchema, columns)
+	for c := range schema {
+		if c < len(m.Header) {
+			schema[c].Name = stripEscapeCodes(m.Header[c])
+		}
+		unique := make(map[string]struct{})
+		for _, value := range m.column(c) {
+			if value == "" {
+				schema[c].Nulls++
+				continue
+			}
+			unique[value] = struct{}{}
+			schema[c].MaxWidth = max(schema[c].MaxWidth, w.fieldWidth(value))
+			schema[c].Type = mergeTypes(schema[c].Type, valueType(value))
+		}
+		schema[c].Unique = len(unique)
+		if schema[c].Type == TypeInteger || schema[c].Type == TypeFloat {
+			schema[c].Aggregates = aggregate(m.column(c))
+		}
+	}
+	return schema
+}
+
+// columnNames returns unique names for the given columns, as required by the exports to databases, which may compare
+// them case-insensitively. Unnamed columns are named after their position, while duplicates get a numeric suffix
+func columnNames(schema []ColumnSchema) []string {
+	names := make([]string, len(schema))
+	used := make(map[string]bool, len(schema))
+	for c, column := range schema {
+		base := column.Name
+		if base == "" {
+			base = "column" + strconv.Itoa(c+1)
+		}
+		name := base
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			name = base + "_" + strconv.Itoa(n)
+		}
+		used[strings.ToLower(name)] = true
+		names[c] = name
+	}
+	return names
+}
+
+// aggregate computes the aggregates of the given numeric values, ignoring the empty and non-finite ones, which
+// cannot be encoded as JSON
+func aggregate(values []string) *ColumnAggregates {
+	a := &ColumnAggregates{}
+	for _, value := range values {
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			continue
+		}
+		if a.Count == 0 {
+			a.Min, a.Max = n, n
+		}
+		a.Count++
+		a.Min, a.Max = min(a.Min, n), max(a.Max, n)
+		a.Sum += n
+	}
+	if a.Count > 0 {
+		a.Mean = a.Sum / float64(a.Count)
+	}
+	return a
+}
+
+// valueType detects the type of a single non-empty value. Only finite numbers are numeric, while booleans are the
+// literal true and false, in any case, so that flags like "t" and "1" are not mistaken for them
+func valueType(value string) ColumnType {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return TypeInteger
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(n) && !math.IsInf(n, 0) {
+		return TypeFloat
+	}
+	if _, ok := parseBool(value); ok {
+		return TypeBool
+	}
+	for _, layout := range []string{time.RFC3339, time.DateTime, time.DateOnly} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return TypeTime
+		}
+	}
+	return TypeString
+}
+
+// parseBool parses the literal true and false, in any case, reporting whether the value is one of them
+func parseBool(value string) (bool, bool) {
+	switch {
+	case strings.EqualFold(value, "true"):
+		return true, true
+	case strings.EqualFold(value, "false"):
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// mergeTypes returns the type of a column holding values of both the given types.
+// Integers and floats result in floats, while any other mix results in strings
+func mergeTypes(a, b ColumnType) ColumnType {
+	switch {
+	case a == TypeUnknown || a == b:
+		return b
+	case (a == TypeInteger && b == TypeFloat) || (a == TypeFloat && b == TypeInteger):
+		return TypeFloat
+	default:
+		return TypeString
+	}
+}
@@ -0,0 +1,40 @@
+package TableWriter
+
+import "testing"
+
+func TestValueType(t *testing.T) {
+	tests := []struct {
+		value string
+		want  ColumnType
+	}{
+		{value: "42", want: TypeInteger},
+		{value: "-7", want: TypeInteger},
+		{value: "1", want: TypeInteger},
+		{value: "0", want: TypeInteger},
+		{value: "1.5", want: TypeFloat},
+		{value: "3e2", want: TypeFloat},
+		{value: "NaN", want: TypeString},
+		{value: "Inf", want: TypeString},
+		{value: "-infinity", want: TypeString},
+		{value: "true", want: TypeBool},
+		{value: "FALSE", want: TypeBool},
+		{value: "tRuE", want: TypeBool},
+		{value: "t", want: TypeString},
+		{value: "F", want: TypeString},
+		{value: "2024-01-02", want: TypeTime},
+		{value: "abc", want: TypeString},
+	}
+	for _, tt := range tests {
+		if got := valueType(tt.value); got != tt.want {
+			t.Errorf("valueType(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAggregateSkipsNonFiniteValues(t *testing.T) {
+	got := *aggregate([]string{"1", "", "NaN", "+Inf", "3", "-inf"})
+	want := ColumnAggregates{Count: 2, Min: 1, Max: 3, Sum: 4, Mean: 2}
+	if got != want {
+		t.Errorf("aggregate() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package TableWriter
+
+import (
+	"errors"
+	"os/signal"
+)
+
+// Escape sequences used to manage the terminal's screen when [AlternateScreen] is set
+const (
+	enterAltScreen = "\033[?1049h"
+	leaveAltScreen = "\033[?1049l"
+	hideCursor     = "\033[?25l"
+	showCursor     = "\033[?25h"
+	redrawScreen   = "\033[H\033[2J"
+)
+
+// screenBuffer prepares the given formatted buffer to be drawn on the alternate screen, which is entered by the
+// first render. Each render replaces the previous one, starting from the top-left corner of the screen, unless
+// [FollowMode] is set and rows are appended to the ones already drawn
+func (w *Writer) screenBuffer(formattedBuffer []byte) []byte {
+	if w.flags&AlternateScreen == 0 {
+		return formattedBuffer
+	}
+	screenBuffer := make([]byte, 0, len(enterAltScreen)+len(hideCursor)+len(redrawScreen)+len(formattedBuffer))
+	if !w.altScreen {
+		screenBuffer = append(screenBuffer, enterAltScreen+hideCursor+redrawScreen...)
+		w.altScreen = true
+		w.watchResize()
+	} else if w.flags&FollowMode == 0 {
+		screenBuffer = append(screenBuffer, redrawScreen...)
+	}
+	return append(screenBuffer, formattedBuffer...)
+}
+
+// refreshTerminalSize updates the terminal's size if it has been resized since the previous render
+func (w *Writer) refreshTerminalSize() {
+	select {
+	case <-w.resize:
+		w.measureTerminal()
+	default:
+	}
+}
+
+// Close implements [io.Closer]. It renders any buffered data, closes the table rendered in [FollowMode] and tears
+// down the live-mode state: the cursor is shown again, the terminal's original screen is restored, the resize
+// listener is stopped and the asciicast recording ends. The [Writer] can still be used afterwards, as a new one
+func (w *Writer) Close() error {
+	var err error
+	if w.follow.header != nil {
+		err = w.EndTable()
+	} else if len(w.buffer) > 0 {
+		err = w.render()
+	}
+
+	if w.resize != nil {
+		signal.Stop(w.resize)
+		w.resize = nil
+	}
+	if w.altScreen {
+		w.altScreen = false
+		err = errors.Join(err, w.write([]byte(showCursor+leaveAltScreen)))
+	}
+	w.cast = nil
+	w.buffer = nil
+	return err
+}
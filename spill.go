@@ -0,0 +1,138 @@
+package TableWriter
+
+import (
+	"bytes"
+	"errors"
+)
+
+// ErrTooManyRows is returned by [Writer.Write] when the received content would exceed the maximum number of buffered
+// rows and the [SpillError] policy is used. The content is rejected as a whole, so that no partial rows are buffered
+var ErrTooManyRows = errors.New("maximum number of buffered rows exceeded")
+
+// SpillPolicy defines how the rows exceeding the maximum number of buffered rows are handled
+type SpillPolicy uint
+
+const (
+	// DropOldest discards the oldest buffered rows to make room for the new ones. When the table is drawn on the
+	// [AlternateScreen], the rows are kept across flushes, so that each redraw shows a rolling window of the latest ones
+	DropOldest SpillPolicy = iota
+	// DropNewest silently discards the new rows until the next flush
+	DropNewest
+	// SpillError rejects the new rows with [ErrTooManyRows]
+	SpillError
+)
+
+// String returns the name of the policy
+func (p SpillPolicy) String() string {
+	switch p {
+	case DropOldest:
+		return "drop-oldest"
+	case DropNewest:
+		return "drop-newest"
+	case SpillError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// SetMaxBufferedRows limits the number of data rows that can be buffered between two flushes, so that long-running
+// collectors cannot grow unbounded. The exceeding rows are handled according to the given [SpillPolicy], while the
+// header is always preserved. Zero disables the limit
+func (w *Writer) SetMaxBufferedRows(n int, policy SpillPolicy) {
+	w.maxRows = max(n, 0)
+	w.spillPolicy = policy
+}
+
+// WithMaxBufferedRows limits the number of data rows that can be buffered between two flushes.
+// See [Writer.SetMaxBufferedRows]
+func WithMaxBufferedRows(n int, policy SpillPolicy) Option {
+	return func(w *Writer) {
+		w.SetMaxBufferedRows(n, policy)
+	}
+}
+
+// isRolling reports whether the buffered rows are kept across flushes, as a rolling window redrawn in place
+func (w *Writer) isRolling() bool {
+	return w.maxRows > 0 && w.spillPolicy == DropOldest &&
+		w.flags&AlternateScreen != 0 && w.flags&FollowMode == 0
+}
+
+// bufferRows appends the given content to the buffer, handling the rows exceeding the maximum number of buffered
+// rows according to the [SpillPolicy]
+func (w *Writer) bufferRows(buf []byte) error {
+	data := append(w.buffer, buf...)
+	start := w.dataStart(data)
+	if w.maxRows == 0 || start < 0 {
+		w.buffer = data
+		return nil
+	}
+	rows := countLines(data[start:])
+	if rows <= w.maxRows {
+		w.buffer = data
+		return nil
+	}
+
+	switch w.spillPolicy {
+	case SpillError:
+		return ErrTooManyRows
+	case DropNewest:
+		w.buffer = data[:start+lineEnd(data[start:], w.maxRows)]
+	default:
+		end := start + lineEnd(data[start:], rows-w.maxRows)
+		w.releaseLazyRows(bytes.Count(data[:start], []byte{'\n'}), rows-w.maxRows)
+		w.buffer = append(data[:start], data[end:]...)
+	}
+	w.debug("buffered rows spilled", "rows", rows, "max_rows", w.maxRows, "policy", w.spillPolicy)
+	return nil
+}
+
+// dataStart returns the position of the first data row in the given buffered data, right after the header, or -1 if
+// the header has not been completely received yet. Flushes appending rows to a table rendered in [FollowMode] have
+// no header
+func (w *Writer) dataStart(data []byte) int {
+	if w.isFollowing() {
+		return 0
+	}
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return -1
+	}
+	return end + 1
+}
+
+// countLines returns the number of lines in the given data, including the incomplete one at its end
+func countLines(data []byte) int {
+	lines := bytes.Count(data, []byte{'\n'})
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
+
+// lineEnd returns the position right after the n-th line break of the given data, which must contain at least n
+func lineEnd(data []byte, n int) int {
+	end := 0
+	for range n {
+		end += bytes.IndexByte(data[end:], '\n') + 1
+	}
+	return end
+}
+
+// releaseLazyRows discards the values of the rows added by [Writer.AppendRow] standing on the given number of dropped
+// lines, starting from the given one, so that they can be garbage collected. The following rows are moved up
+func (w *Writer) releaseLazyRows(first, n int) {
+	if len(w.lazyRows) == 0 {
+		return
+	}
+	lazyRows := make(map[int][]any, len(w.lazyRows))
+	for line, values := range w.lazyRows {
+		switch {
+		case line < first:
+			lazyRows[line] = values
+		case line >= first+n:
+			lazyRows[line-n] = values
+		}
+	}
+	w.lazyRows = lazyRows
+}
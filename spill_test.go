@@ -0,0 +1,56 @@
+package TableWriter
+
+import (
+	"io"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestAppendRowSpill(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy SpillPolicy
+		want   [][]string
+	}{
+		{name: "drop oldest", policy: DropOldest, want: [][]string{{"2", "4"}, {"r2"}}},
+		{name: "drop newest", policy: DropNewest, want: [][]string{{"0", "0"}, {"r0"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWriter(io.Discard, 0, WithMaxBufferedRows(2, tt.policy))
+			if _, err := io.WriteString(w, "A\tB\n"); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			for i := range 3 {
+				if err := w.AppendRow(i, i*i); err != nil {
+					t.Fatalf("AppendRow() error = %v", err)
+				}
+				if _, err := io.WriteString(w, "r"+strconv.Itoa(i)+"\n"); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if got := w.Model().Rows; !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("Model().Rows = %q, want %q", got, tt.want)
+			}
+			if len(w.lazyRows) != 1 {
+				t.Errorf("%d lazy rows are retained, want 1", len(w.lazyRows))
+			}
+		})
+	}
+}
+
+func TestMaxBufferedRowsError(t *testing.T) {
+	w := NewWriter(io.Discard, 0, WithMaxBufferedRows(2, SpillError))
+	if _, err := io.WriteString(w, "A\tB\n1\t2\n3\t4\n"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	// Rejected content is not buffered at all
+	if _, err := io.WriteString(w, "5\t6\n7"); err != ErrTooManyRows {
+		t.Errorf("Write() error = %v, want %v", err, ErrTooManyRows)
+	}
+	want := [][]string{{"1", "2"}, {"3", "4"}}
+	if got := w.Model().Rows; !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("Model().Rows = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,310 @@
+package TableWriter
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Layout of the SQLite databases written by [Writer.ExportSQLite]. See https://www.sqlite.org/fileformat.html
+const (
+	sqlitePageSize     = 4096
+	sqliteHeaderSize   = 100
+	sqliteLeafPage     = 0x0d
+	sqliteInteriorPage = 0x05
+	// sqliteInteriorCells is the number of cells surely fitting an interior page, each one holding a page number and
+	// a rowid of at most 9 bytes, along with its pointer
+	sqliteInteriorCells = (sqlitePageSize - 12) / (2 + 4 + 9)
+)
+
+// sqliteDatabase holds the pages of a SQLite database being written. Page numbers start from 1
+type sqliteDatabase struct {
+	pages [][]byte
+}
+
+// allocate appends an empty page to the database and returns its number
+func (db *sqliteDatabase) allocate() int {
+	db.pages = append(db.pages, make([]byte, sqlitePageSize))
+	return len(db.pages)
+}
+
+// appendSQLiteVarint appends the given value as a SQLite variable-length integer, which is big-endian and uses the
+// whole ninth byte, if any
+func appendSQLiteVarint(b []byte, v uint64) []byte {
+	if v > 1<<56-1 {
+		var buf [9]byte
+		buf[8] = byte(v)
+		v >>= 8
+		for i := 7; i >= 0; i-- {
+			buf[i] = byte(v&0x7f) | 0x80
+			v >>= 7
+		}
+		return append(b, buf[:]...)
+	}
+	var buf [8]byte
+	n := len(buf)
+	for {
+		n--
+		buf[n] = byte(v&0x7f) | 0x80
+		v >>= 7
+		if v == 0 {
+			break
+		}
+	}
+	buf[len(buf)-1] &^= 0x80
+	return append(b, buf[n:]...)
+}
+
+// sqliteRecord encodes the given values, which can be nil, int64, float64 or string, as a SQLite record
+func sqliteRecord(values ...any) []byte {
+	var types, body []byte
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			types = appendSQLiteVarint(types, 0)
+		case int64:
+			// Integers use the smallest of the available sizes, while 0 and 1 need no body at all
+			serial, size := uint64(6), 8
+			switch {
+			case v == 0 || v == 1:
+				serial, size = uint64(8+v), 0
+			case v >= math.MinInt8 && v <= math.MaxInt8:
+				serial, size = 1, 1
+			case v >= math.MinInt16 && v <= math.MaxInt16:
+				serial, size = 2, 2
+			case v >= -1<<23 && v < 1<<23:
+				serial, size = 3, 3
+			case v >= math.MinInt32 && v <= math.MaxInt32:
+				serial, size = 4, 4
+			case v >= -1<<47 && v < 1<<47:
+				serial, size = 5, 6
+			}
+			types = appendSQLiteVarint(types, serial)
+			body = append(body, binary.BigEndian.AppendUint64(nil, uint64(v))[8-size:]...)
+		case float64:
+			types = appendSQLiteVarint(types, 7)
+			body = binary.BigEndian.AppendUint64(body, math.Float64bits(v))
+		case string:
+			types = appendSQLiteVarint(types, uint64(13+2*len(v)))
+			body = append(body, v...)
+		}
+	}
+	// The header's size includes the varint holding it
+	size := len(types) + 1
+	for len(appendSQLiteVarint(nil, uint64(size))) != size-len(types) {
+		size++
+	}
+	return append(append(appendSQLiteVarint(nil, uint64(size)), types...), body...)
+}
+
+// leafCell encodes a cell of a table's leaf page, holding the given row. The part of the record that does not fit
+// the page is stored into overflow pages, allocated on the fly
+func (db *sqliteDatabase) leafCell(rowid int64, record []byte) []byte {
+	cell := appendSQLiteVarint(appendSQLiteVarint(nil, uint64(len(record))), uint64(rowid))
+	// Thresholds defined by the file format, which depend on the usable size of the pages
+	maxLocal := sqlitePageSize - 35
+	if len(record) <= maxLocal {
+		return append(cell, record...)
+	}
+	minLocal := (sqlitePageSize-12)*32/255 - 23
+	local := minLocal + (len(record)-minLocal)%(sqlitePageSize-4)
+	if local > maxLocal {
+		local = minLocal
+	}
+	cell = append(cell, record[:local]...)
+
+	overflow := db.allocate()
+	cell = binary.BigEndian.AppendUint32(cell, uint32(overflow))
+	for rest := record[local:]; len(rest) > 0; {
+		page := db.pages[overflow-1]
+		n := copy(page[4:], rest)
+		if rest = rest[n:]; len(rest) > 0 {
+			overflow = db.allocate()
+			binary.BigEndian.PutUint32(page, uint32(overflow))
+		}
+	}
+	return cell
+}
+
+// writePage fills the given page with a b-tree page of the given kind, holding the given cells. The header of the
+// first page follows the database's header, while interior pages point to their rightmost child
+func writePage(page []byte, number int, kind byte, cells [][]byte, rightmost int) {
+	header := 0
+	if number == 1 {
+		header = sqliteHeaderSize
+	}
+	page[header] = kind
+	binary.BigEndian.PutUint16(page[header+3:], uint16(len(cells)))
+	pointers := header + 8
+	if kind == sqliteInteriorPage {
+		binary.BigEndian.PutUint32(page[header+8:], uint32(rightmost))
+		pointers = header + 12
+	}
+	content := len(page)
+	for i, cell := range cells {
+		content -= len(cell)
+		copy(page[content:], cell)
+		binary.BigEndian.PutUint16(page[pointers+2*i:], uint16(content))
+	}
+	binary.BigEndian.PutUint16(page[header+5:], uint16(content))
+}
+
+// writeTable stores the given rows into a table b-tree and returns the number of its root page.
+// Rows get consecutive rowids, starting from 1
+func (db *sqliteDatabase) writeTable(records [][]byte) int {
+	type child struct {
+		page  int
+		rowid int64
+	}
+	var level []child
+	var cells [][]byte
+	used := 8
+	flush := func(rowid int64) {
+		page := db.allocate()
+		writePage(db.pages[page-1], page, sqliteLeafPage, cells, 0)
+		level = append(level, child{page, rowid})
+		cells, used = nil, 8
+	}
+	for r, record := range records {
+		cell := db.leafCell(int64(r+1), record)
+		if used+2+len(cell) > sqlitePageSize {
+			flush(int64(r))
+		}
+		cells = append(cells, cell)
+		used += 2 + len(cell)
+	}
+	flush(int64(len(records)))
+
+	// Interior levels are added until a single page holds the whole tree, spreading the children evenly so that no
+	// page is left with a single one
+	for len(level) > 1 {
+		pages := (len(level) + sqliteInteriorCells) / (sqliteInteriorCells + 1)
+		var parents []child
+		for p := range pages {
+			children := level[p*len(level)/pages : (p+1)*len(level)/pages]
+			cells := make([][]byte, 0, len(children)-1)
+			for _, c := range children[:len(children)-1] {
+				cells = append(cells, appendSQLiteVarint(binary.BigEndian.AppendUint32(nil, uint32(c.page)), uint64(c.rowid)))
+			}
+			last := children[len(children)-1]
+			page := db.allocate()
+			writePage(db.pages[page-1], page, sqliteInteriorPage, cells, last.page)
+			parents = append(parents, child{page, last.rowid})
+		}
+		level = parents
+	}
+	return level[0].page
+}
+
+// quoteSQLiteName quotes the given identifier for SQL statements
+func quoteSQLiteName(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// sqliteColumn returns the type declared for the columns of the given type and the function converting their
+// values. Values that cannot be converted, like NaN, are stored as text
+func sqliteColumn(t ColumnType) (string, func(string) any) {
+	switch t {
+	case TypeInteger:
+		return "INTEGER", func(s string) any {
+			if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return n
+			}
+			return s
+		}
+	case TypeFloat:
+		return "REAL", func(s string) any {
+			if n, err := strconv.ParseFloat(s, 64); err == nil && !math.IsNaN(n) {
+				return n
+			}
+			return s
+		}
+	case TypeBool:
+		return "INTEGER", func(s string) any {
+			if b, ok := parseBool(s); ok && b {
+				return int64(1)
+			} else if ok {
+				return int64(0)
+			}
+			return s
+		}
+	default:
+		return "TEXT", func(s string) any { return s }
+	}
+}
+
+// ExportSQLite writes the buffered data, without consuming it, to a new SQLite database at the given path, holding
+// a single table with the given name, so that it can be queried with SQL. Columns are named after the header's fields
+// and typed according to [Writer.InferSchema], while empty fields are stored as NULL.
+// Existing files are never overwritten
+func (w *Writer) ExportSQLite(path, tableName string) error {
+	if tableName == "" || strings.HasPrefix(strings.ToLower(tableName), "sqlite_") {
+		return fmt.Errorf("invalid table name %q", tableName)
+	}
+	m := w.exportModel()
+	schema := w.inferSchema(m)
+
+	converters := make([]func(string) any, len(schema))
+	definitions := make([]string, len(schema))
+	for c, name := range columnNames(schema) {
+		var declared string
+		declared, converters[c] = sqliteColumn(schema[c].Type)
+		definitions[c] = quoteSQLiteName(name) + " " + declared
+	}
+	statement := "CREATE TABLE " + quoteSQLiteName(tableName) + " (" + strings.Join(definitions, ", ") + ")"
+
+	db := &sqliteDatabase{}
+	// The first page holds the schema, which is written once the table's root page is known
+	db.allocate()
+	records := make([][]byte, 0, len(m.Rows))
+	for _, row := range m.Rows {
+		values := make([]any, len(schema))
+		for c := range schema {
+			if value := stripEscapeCodes(fieldAt(row, c)); value != "" {
+				values[c] = converters[c](value)
+			}
+		}
+		records = append(records, sqliteRecord(values...))
+	}
+	root := db.writeTable(records)
+
+	master := db.leafCell(1, sqliteRecord("table", tableName, tableName, int64(root), statement))
+	if sqliteHeaderSize+8+2+len(master) > sqlitePageSize {
+		return errors.New("table definition too long for a SQLite export")
+	}
+	writePage(db.pages[0], 1, sqliteLeafPage, [][]byte{master}, 0)
+
+	header := db.pages[0]
+	copy(header, "SQLite format 3\x00")
+	binary.BigEndian.PutUint16(header[16:], sqlitePageSize)
+	// File format versions, reserved space and payload fractions
+	copy(header[18:], []byte{1, 1, 0, 64, 32, 32})
+	binary.BigEndian.PutUint32(header[24:], 1) // File change counter
+	binary.BigEndian.PutUint32(header[28:], uint32(len(db.pages)))
+	binary.BigEndian.PutUint32(header[40:], 1) // Schema cookie
+	binary.BigEndian.PutUint32(header[44:], 4) // Schema format
+	binary.BigEndian.PutUint32(header[56:], 1) // UTF-8 text encoding
+	binary.BigEndian.PutUint32(header[92:], 1) // Change counter the database's size refers to
+	binary.BigEndian.PutUint32(header[96:], 3046000)
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	for _, page := range db.pages {
+		if _, err = f.Write(page); err != nil {
+			break
+		}
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package TableWriter
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportSQLiteRoundTrip(t *testing.T) {
+	sqlite, err := exec.LookPath("sqlite3")
+	if err != nil {
+		t.Skip("sqlite3 is not installed")
+	}
+	w := NewWriter(io.Discard, 0)
+	var sb strings.Builder
+	sb.WriteString("ID\tName\tScore\tActive\tName\n")
+	// Enough rows to need interior pages, along with a value spanning multiple overflow pages
+	for i := range 3000 {
+		fmt.Fprintf(&sb, "%d\tuser \"%d\"\t%d.5\t%t\tx\n", i, i, i, i%2 == 0)
+	}
+	sb.WriteString("3000\t" + strings.Repeat("long", 5000) + "\t\ttrue\ty\n")
+	if _, err = io.WriteString(w, sb.String()); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "table.db")
+	if err = w.ExportSQLite(path, "my table"); err != nil {
+		t.Fatalf("ExportSQLite() error = %v", err)
+	}
+
+	queries := []struct {
+		query string
+		want  string
+	}{
+		{query: "PRAGMA integrity_check", want: "ok"},
+		{
+			query: `SELECT sql FROM sqlite_schema`,
+			want:  `CREATE TABLE "my table" ("ID" INTEGER, "Name" TEXT, "Score" REAL, "Active" INTEGER, "Name_2" TEXT)`,
+		},
+		{query: `SELECT count(*), sum(Active), count(Score) FROM "my table"`, want: "3001|1501|3000"},
+		{query: `SELECT Name, typeof(Score), Score FROM "my table" WHERE ID = 1234`, want: `user "1234"|real|1234.5`},
+		{query: `SELECT length(Name), Name_2 FROM "my table" WHERE ID = 3000`, want: "20000|y"},
+	}
+	for _, q := range queries {
+		out, err := exec.Command(sqlite, path, q.query).CombinedOutput()
+		if got := strings.TrimSpace(string(out)); err != nil || got != q.want {
+			t.Errorf("%s = %q (error %v), want %q", q.query, got, err, q.want)
+		}
+	}
+}
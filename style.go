@@ -0,0 +1,61 @@
+package TableWriter
+
+// Style defines the set of box-drawing characters used to draw the table's borders and dividers.
+// Styles are ignored when [AsciiTable] is set
+type Style uint
+
+const (
+	// StyleDefault draws the table with light lines (┌─┬─┐)
+	StyleDefault Style = iota
+	// StyleRounded draws the table with light lines and rounded corners (╭─┬─╮)
+	StyleRounded
+	// StyleDouble draws the table with double lines (╔═╦═╗)
+	StyleDouble
+	// StyleHeavy draws the table with heavy lines (┏━┳━┓)
+	StyleHeavy
+	// StyleDotted draws the table with dashed lines (┌┄┬┄┐)
+	StyleDotted
+	// StyleMinimal draws only the horizontal lines of the table, separating the columns with spaces
+	StyleMinimal
+)
+
+// styleDividers holds the dividers of each [Style]
+var styleDividers = map[Style]Dividers{
+	StyleDefault: {
+		HLine: "─", VLine: "│", TL: "┌", TR: "┐", BL: "└", BR: "┘",
+		TUp: "┬", TDown: "┴", Cross: "┼", VLeft: "├", VRight: "┤",
+	},
+	StyleRounded: {
+		HLine: "─", VLine: "│", TL: "╭", TR: "╮", BL: "╰", BR: "╯",
+		TUp: "┬", TDown: "┴", Cross: "┼", VLeft: "├", VRight: "┤",
+	},
+	StyleDouble: {
+		HLine: "═", VLine: "║", TL: "╔", TR: "╗", BL: "╚", BR: "╝",
+		TUp: "╦", TDown: "╩", Cross: "╬", VLeft: "╠", VRight: "╣",
+	},
+	StyleHeavy: {
+		HLine: "━", VLine: "┃", TL: "┏", TR: "┓", BL: "┗", BR: "┛",
+		TUp: "┳", TDown: "┻", Cross: "╋", VLeft: "┣", VRight: "┫",
+	},
+	StyleDotted: {
+		HLine: "┄", VLine: "┆", TL: "┌", TR: "┐", BL: "└", BR: "┘",
+		TUp: "┬", TDown: "┴", Cross: "┼", VLeft: "├", VRight: "┤",
+	},
+	StyleMinimal: {
+		HLine: "─", VLine: " ", TL: "─", TR: "─", BL: "─", BR: "─",
+		TUp: "─", TDown: "─", Cross: "─", VLeft: "─", VRight: "─",
+	},
+}
+
+// SetStyle selects the box-drawing characters used to draw the table's borders and dividers
+func (w *Writer) SetStyle(style Style) {
+	w.style = style
+	w.initDividers()
+}
+
+// WithStyle selects the box-drawing characters used to draw the table. See [Writer.SetStyle]
+func WithStyle(style Style) Option {
+	return func(w *Writer) {
+		w.style = style
+	}
+}
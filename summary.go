@@ -0,0 +1,100 @@
+package TableWriter
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// ColumnSummary defines the statistic displayed next to a column's header, computed over the column's data rows
+type ColumnSummary uint
+
+const (
+	// SummaryNone leaves the header untouched
+	SummaryNone ColumnSummary = iota
+	// SummaryCount appends the number of non-empty fields, e.g. "Name (42)"
+	SummaryCount
+	// SummaryUnique appends the number of distinct non-empty fields, e.g. "Status (7 uniq)"
+	SummaryUnique
+)
+
+// annotateHeader appends the requested summaries to the header's fields.
+// The first row is considered to be the header, while all the following ones are considered data rows
+func (w *Writer) annotateHeader() {
+	if len(w.rows) == 0 {
+		return
+	}
+	header := w.rows[0]
+	for c := range header {
+		var annotation string
+		switch w.columnSpec(c).Summary {
+		case SummaryCount:
+			annotation = fmt.Sprintf(" (%d)", len(w.columnValues(c)))
+		case SummaryUnique:
+			unique := make(map[string]struct{})
+			for _, value := range w.columnValues(c) {
+				unique[value] = struct{}{}
+			}
+			annotation = fmt.Sprintf(" (%d uniq)", len(unique))
+		default:
+			continue
+		}
+		header[c].text += annotation
+		header[c].plain += annotation
+	}
+}
+
+// columnValues returns the colorless non-empty fields of the given column, excluding the header and the rows
+// rendered by templates
+func (w *Writer) columnValues(c int) []string {
+	values := make([]string, 0, len(w.rows))
+	for _, cells := range w.rows[min(1, len(w.rows)):] {
+		if c < len(cells) && cells[c].plain != "" && !w.isTemplateRow(cells) {
+			values = append(values, cells[c].plain)
+		}
+	}
+	return values
+}
+
+// TopK returns a frequency table of the k most common non-empty values of the given column in the buffered data.
+// See [Model.TopK]
+func (w *Writer) TopK(col, k int) *Model {
+	return w.Model().TopK(col, k)
+}
+
+// TopK returns a frequency table of the k most common non-empty values of the given column, with a row for each
+// value reporting its count and its percentage over all the non-empty values. Values with the same count are sorted
+// alphabetically. If k is not positive, all the values are reported
+func (m *Model) TopK(col, k int) *Model {
+	name := "Value"
+	if col < len(m.Header) {
+		name = stripEscapeCodes(m.Header[col])
+	}
+	counts := make(map[string]int)
+	total := 0
+	for _, value := range m.column(col) {
+		if value != "" {
+			counts[value]++
+			total++
+		}
+	}
+
+	values := make([]string, 0, len(counts))
+	for value := range counts {
+		values = append(values, value)
+	}
+	slices.SortFunc(values, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), cmp.Compare(a, b))
+	})
+	if k > 0 && k < len(values) {
+		values = values[:k]
+	}
+
+	topK := &Model{Header: []string{name, "Count", "Percent"}, Rows: make([][]string, 0, len(values))}
+	for _, value := range values {
+		percent := float64(counts[value]) * 100 / float64(total)
+		topK.Rows = append(topK.Rows, []string{value, strconv.Itoa(counts[value]), fmt.Sprintf("%.1f%%", percent)})
+	}
+	return topK
+}
@@ -0,0 +1,54 @@
+package TableWriter
+
+import (
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	w := NewWriter(io.Discard, 0)
+	input := "Name\tCity\nA\tRome\nB\t\x1b[31mParis\x1b[0m\nC\tRome\nD\nE\tBerlin\nF\tParis\n"
+	if _, err := io.WriteString(w, input); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	tests := []struct {
+		name string
+		col  int
+		k    int
+		want Model
+	}{
+		{
+			name: "ties sorted alphabetically",
+			col:  1,
+			k:    2,
+			want: Model{
+				Header: []string{"City", "Count", "Percent"},
+				Rows:   [][]string{{"Paris", "2", "40.0%"}, {"Rome", "2", "40.0%"}},
+			},
+		},
+		{
+			name: "all values",
+			col:  1,
+			k:    0,
+			want: Model{
+				Header: []string{"City", "Count", "Percent"},
+				Rows:   [][]string{{"Paris", "2", "40.0%"}, {"Rome", "2", "40.0%"}, {"Berlin", "1", "20.0%"}},
+			},
+		},
+		{
+			name: "missing column",
+			col:  5,
+			k:    3,
+			want: Model{Header: []string{"Value", "Count", "Percent"}, Rows: [][]string{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := w.TopK(tt.col, tt.k)
+			if !slices.Equal(got.Header, tt.want.Header) || !slices.EqualFunc(got.Rows, tt.want.Rows, slices.Equal) {
+				t.Errorf("TopK(%d, %d) = %q, want %q", tt.col, tt.k, *got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package TableWriter
+
+import (
+	"strings"
+	"text/template"
+)
+
+// SetSummaryLine renders a line below the table, built from the given [text/template] filled with the render's
+// [RenderStats]. For example, "{{.Rows}} rows, {{.Columns}} columns, {{.Truncations}} truncated" is rendered as
+// "42 rows, 7 columns, 3 truncated". Since the line is part of the render, the written bytes and the render's
+// duration are not available. The line is omitted in [FollowMode] or when the template cannot be resolved.
+// An empty format disables the line
+func (w *Writer) SetSummaryLine(format string) {
+	w.summaryLine = format
+}
+
+// WithSummaryLine renders a line below the table, built from the render's statistics. See [Writer.SetSummaryLine]
+func WithSummaryLine(format string) Option {
+	return func(w *Writer) {
+		w.SetSummaryLine(format)
+	}
+}
+
+// renderSummaryLine returns the summary line to be rendered below the table, if any
+func (w *Writer) renderSummaryLine() []byte {
+	if w.summaryLine == "" || w.flags&FollowMode != 0 || len(w.rows) == 0 {
+		return nil
+	}
+	var sb strings.Builder
+	tmpl, err := template.New("summary").Parse(w.summaryLine)
+	if err == nil {
+		err = tmpl.Execute(&sb, w.stats)
+	}
+	if err != nil {
+		w.debug("summary line omitted", "error", err)
+		return nil
+	}
+	return []byte(strings.TrimSuffix(sb.String(), "\n") + "\n")
+}
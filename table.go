@@ -0,0 +1,64 @@
+package TableWriter
+
+import "io"
+
+// Column defines how a column of a [Table] is built from the rendered items
+type Column[T any] struct {
+	// Header is the column's header
+	Header string
+	// Extract returns the column's field for the given item
+	Extract func(T) string
+	// Align is the alignment of the column's fields. See [ColumnSpec]
+	Align Alignment
+	// Width is the maximum width of the column. Zero means unlimited
+	Width int
+}
+
+// Table renders items of type T through a [Writer], according to its column definitions.
+// It is a type-safe alternative to writing tab-separated lines, whose columns are checked at compile time
+type Table[T any] struct {
+	columns []Column[T]
+	writer  *Writer
+}
+
+// NewTable allocates a new [Table] rendering its items to the given output.
+// Flags and options configure the underlying [Writer], as for [NewWriter]
+func NewTable[T any](output io.Writer, flags uint, columns []Column[T], opts ...Option) *Table[T] {
+	t := &Table[T]{columns: columns, writer: NewWriter(output, flags, opts...)}
+	for c, column := range columns {
+		spec := t.writer.columnSpec(c)
+		spec.Align = column.Align
+		spec.MaxWidth = column.Width
+		t.writer.SetColumnSpec(c, spec)
+	}
+	return t
+}
+
+// Writer returns the [Writer] used to render the table, so that it can be further configured
+func (t *Table[T]) Writer() *Writer {
+	return t.writer
+}
+
+// Render writes the header followed by a row for each item, and flushes the table.
+// Fields are extracted at render time, and only for the columns that are displayed
+func (t *Table[T]) Render(items []T) error {
+	header := make([]any, len(t.columns))
+	for c, column := range t.columns {
+		header[c] = column.Header
+	}
+	if err := t.writer.AppendRow(header...); err != nil {
+		return err
+	}
+	for _, item := range items {
+		fields := make([]any, len(t.columns))
+		for c, column := range t.columns {
+			fields[c] = func() string {
+				return column.Extract(item)
+			}
+		}
+		if err := t.writer.AppendRow(fields...); err != nil {
+			return err
+		}
+	}
+	return t.writer.Flush()
+}
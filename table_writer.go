@@ -2,17 +2,31 @@ package TableWriter
 
 import (
 	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
 	"io"
+	"log/slog"
+	"maps"
 	"os"
-	"regexp"
+	"slices"
 	"strings"
-	"unicode"
+	"text/template"
+	"time"
+	"unicode/utf8"
 )
 
-var escapeColorCodesRegex = regexp.MustCompile(`\033\[[0-9;]+m`)
+// ErrRender is returned by [Writer.Flush] when the table cannot be rendered because of an unexpected internal state.
+// The buffered data is discarded and nothing is written to the output
+var ErrRender = errors.New("unable to render the table")
+
+// ErrBufferFull is returned by [Writer.Write] when the received content would exceed the maximum buffer size.
+// The content is rejected as a whole, so that no partial rows are buffered
+var ErrBufferFull = errors.New("maximum buffer size exceeded")
 
 const (
-	// StripColours Removes ANSI color codes from output text
+	// StripColours Removes ANSI color codes and terminal hyperlinks from output text.
+	// It is set automatically when colors are disabled by the NO_COLOR or FORCE_COLOR environment variables
 	StripColours uint = 1 << iota
 	// AlignMiddle centers the text horizontally in the column
 	AlignMiddle
@@ -26,104 +40,335 @@ const (
 	// AsciiTable allows using only ASCII divider.
 	// Useful for environments that do not support utf-8 encodings
 	AsciiTable
+	// MarkWrappedLines prefixes each continuation line of wrapped fields with a marker (↪)
+	MarkWrappedLines
+	// CopyFriendly renders vertical borders as spaces, while keeping the horizontal ones.
+	// Useful to select and copy rows from the terminal without capturing any border character
+	CopyFriendly
+	// FollowMode appends the rows of each flush to the table rendered by the first one, reusing its header and its
+	// columns' widths. When writing to a terminal, the header is repeated every screenful
+	FollowMode
+	// DropTrailingTab ignores a single tab at the end of each line, so that rows written as "a\tb\t" have two fields.
+	// By default, a trailing tab opens an empty field, which is rendered as an empty cell
+	DropTrailingTab
+	// AlternateScreen draws the table on the terminal's alternate screen, redrawing it in place at each flush.
+	// Useful for live and watch modes, as the original screen is restored by [Writer.Close]
+	AlternateScreen
+	// HighlightChanges colors the fields whose value changed since the previous flush, matching the rows by their key.
+	// See [Writer.SetRowKey]
+	HighlightChanges
+	// AlignNumbers right-aligns the columns whose data fields are all numeric, unless they have their own alignment.
+	// See [Writer.SetColumnAlignment]
+	AlignNumbers
+	// PreserveCombiningMarks keeps the non-spacing combining marks (e.g. the accents of decomposed Vietnamese text and
+	// the vowel signs of Hindi), which are displayed over the preceding character, instead of removing them
+	PreserveCombiningMarks
+	// ShowLegend renders a legend below the table explaining the colors applied to it, e.g. to the changed fields
+	// and to the trend arrows, so that screenshots remain self-explanatory
+	ShowLegend
+	// InlineMarkup translates the inline markup of the fields (**bold**, _dim_ and `code`) into ANSI styles, so that
+	// producers can express emphasis without embedding escape codes. The markup is removed by [StripColours]
+	InlineMarkup
+	// AutoStripColours sets [StripColours] when the output is not a terminal, like files and pipes, unless colors are
+	// forced by the FORCE_COLOR environment variable
+	AutoStripColours
+	// TTYFallback measures the controlling terminal (/dev/tty) when the output is redirected and its size cannot be
+	// retrieved, so that commands like `mytool | tee log` still fit the visible terminal
+	TTYFallback
+	// Compact keeps the vertical separators but drops the horizontal lines between data rows, halving the height of
+	// big listings. The line under the header, the outer border and the guides are still drawn
+	Compact
 )
 
 // column represents the base structure to keep track of each table's column width over time
 // This is later used to determine the minimum columns' width and related fields' padding
 type column struct {
 	textWidth int
+	hidden    bool
+	numeric   bool
+	barMax    float64 // Largest value of the column, when drawn as bars
+	barLabel  int     // Width of the widest value of the column, when drawn as bars
+}
+
+// cell represents a single table's field, both as received from the buffer and as it is going to be rendered
+type cell struct {
+	text     string   // Field's content, including ANSI escape codes
+	plain    string   // Field's content without ANSI escape codes
+	segments []string // Rendered content, one entry for each physical line the field spans over
+	prefix   string   // Zero-width sequence emitted before the field's content
+	kind     string   // Kind of the row rendered by a template, if any
 }
 
 // Writer the [io.Writer] struct used to process and format received text in order to create nice looking tables
 // and style them according to the specified flags
 type Writer struct {
 	// Configuration
-	output  io.Writer
-	divider dividers
-	flags   uint
+	output          io.Writer
+	divider         Dividers
+	dividers        *Dividers
+	flags           uint
+	columnSpecs     map[int]ColumnSpec
+	defaultSpec     ColumnSpec
+	maxRowLines     int
+	fixedWidths     []int
+	guideEvery      int
+	guideMode       GuideMode
+	tableAlign      Alignment
+	frame           Frame
+	style           Style
+	borders         Borders
+	borderColor     string
+	cellStyle       CellStyle
+	rowStyles       map[int]CellStyle
+	cellStyles      map[[2]int]CellStyle
+	format          OutputFormat
+	lineEnding      LineEnding
+	encoding        ExportEncoding
+	crPolicy        CarriageReturnPolicy
+	maxBuffer       int
+	maxRows         int
+	spillPolicy     SpillPolicy
+	binaryThreshold float64
+	defaultWidth    int
+	width           int
+	height          int
+	keyCol          int
+	emojiWidth      int
+	emojiPolicy     EmojiWidth
+	profiles        []terminalProfile
+	ambiguousWidth  int
+	negotiator      LayoutNegotiator
+	logger          *slog.Logger
+	statsHook       func(RenderStats)
+	eventHandler    func(Event)
+	minInterval     time.Duration
+	groups          []columnGroup
+	presets         map[string]ResponsivePreset
+	preset          string
+	summaryLine     string
+	sanitizePolicy  map[string]SanitizeAction
+	sanitizer       func(rune) rune
+	rowTemplates    map[string]*template.Template
+	renames         map[string]string
+	metadata        map[string]any
 
 	// State
-	termCols int
-	buffer   []byte
-	columns  []column
-	lines    []string
+	termCols   int
+	termRows   int
+	buffer     []byte
+	columns    []column
+	rows       [][]cell
+	footnotes  []footnote
+	follow     followState
+	stats      RenderStats
+	lastRender time.Time
+	written    int64
+	lazyRows   map[int][]any
+	altScreen  bool
+	resize     chan os.Signal
+	truncated  map[CellPosition]string
+	layout     []int
+	legend     legendEntry
+	previous   map[string][]string
+	pseudonyms map[string]map[string]int
+	history    history
+	cast       *asciicast
 }
 
 // NewWriter allocates and initializes a new [Writer].
 // The parameters are the same as for the init function.
-func NewWriter(output io.Writer, flags uint) *Writer {
-	return new(Writer).init(output, flags)
+func NewWriter(output io.Writer, flags uint, opts ...Option) *Writer {
+	return new(Writer).init(output, flags, opts...)
 }
 
 // Write appends the external content received to the [Writer]'s internal buffer
 // This is automatically called by functions piping data into this io.Writer
+// If a maximum buffer size is set, content that would exceed it is rejected with [ErrBufferFull], while content that
+// looks like binary data can be rejected with [ErrBinaryData]. Rows exceeding the maximum number of buffered rows are
+// handled according to its [SpillPolicy]
 func (w *Writer) Write(buf []byte) (n int, err error) {
-	w.buffer = append(w.buffer, buf...)
+	if w.maxBuffer > 0 && len(w.buffer)+len(buf) > w.maxBuffer {
+		return 0, ErrBufferFull
+	}
+	if w.isBinary(buf) {
+		return 0, ErrBinaryData
+	}
+	if err := w.bufferRows(buf); err != nil {
+		return 0, err
+	}
 	return len(buf), nil
 }
 
-// cleanInvisibleChars removes all control, format, non-spacing, and
-// non-standard space characters (Zs).
-// This preserve \t, \n, and the ANSI escape character (\x1b).
-func cleanInvisibleChars(s string) string {
-	return strings.Map(func(r rune) rune {
-		// Do nothing with characters that are needed by the Writer to correctly format the output
-		if r == ' ' || r == '\x1b' || r == '\t' || r == '\n' {
-			return r
-		}
-
-		// Removing invisible characters that cause misalignment
-		// Unicode categories to remove:
-		// Cf: format character (zero width joiner, LTR/RTL symbols)
-		// Cc: control character (null, carriage return other than \n)
-		// Mn: non-spacing characters (Accents or symbols that take no space)
-		// Zs: space separator (non-breaking space: U+00A0 etc.)
-		if unicode.Is(unicode.Cf, r) ||
-			unicode.Is(unicode.Cc, r) ||
-			unicode.Is(unicode.Mn, r) ||
-			unicode.Is(unicode.Zs, r) {
-			return -1
-		}
+// SetMaxBufferSize limits the amount of bytes that can be buffered between two flushes, so that the [Writer] can
+// safely back untrusted or memory-constrained pipelines. Zero disables the limit
+func (w *Writer) SetMaxBufferSize(size int) {
+	w.maxBuffer = max(size, 0)
+}
 
-		// Any uncaught space character is removed to avoid alignment problems
-		if unicode.IsSpace(r) {
-			return -1
-		}
+// WithMaxBufferSize limits the amount of bytes that can be buffered between two flushes.
+// See [Writer.SetMaxBufferSize]
+func WithMaxBufferSize(size int) Option {
+	return func(w *Writer) {
+		w.SetMaxBufferSize(size)
+	}
+}
 
-		// Preserves everything else
-		return r
-	}, s)
+// BytesWritten returns the total amount of bytes written to the output since the [Writer] was created,
+// including the ones of partial writes
+func (w *Writer) BytesWritten() int64 {
+	return w.written
 }
 
 // Flush processes the output buffer by creating the corresponding table content and sends it to the chosen
 // output's file descriptor
+// When a minimum interval between renders is set, flushes occurring too early are coalesced with the next one
 func (w *Writer) Flush() (err error) {
+	if w.isRateLimited() {
+		return nil
+	}
+	return w.render()
+}
+
+// render formats the buffered data and writes it to the output, regardless of any rate limit
+func (w *Writer) render() (err error) {
+	// Rolling windows are redrawn from the same rows at the next flush
+	if w.isRolling() {
+		buffer, lazyRows := slices.Clone(w.buffer), maps.Clone(w.lazyRows)
+		defer func() {
+			w.buffer, w.lazyRows = buffer, lazyRows
+		}()
+	}
 	defer w.Clear()
-	cleanedBuffer := cleanInvisibleChars(string(w.buffer))
-	w.lines = strings.Split(cleanedBuffer, "\n")
-	if len(w.lines[len(w.lines)-1]) == 0 {
-		w.lines = w.lines[:len(w.lines)-1]
+	// Malformed data must never crash the application embedding the Writer
+	defer func() {
+		if r := recover(); r != nil {
+			w.debug("render aborted", "panic", r)
+			err = fmt.Errorf("%w: %v", ErrRender, r)
+		}
+	}()
+	start := time.Now()
+	w.lastRender = start
+	w.stats = RenderStats{}
+	w.legend = 0
+	w.truncated = make(map[CellPosition]string)
+	w.refreshTerminalSize()
+	w.emit(RenderStarted{})
+	w.parseRows(w.bufferedRows())
+	if w.format != FormatTable {
+		w.renderHeader()
+		w.anonymizeFields()
+		// Lines are terminated before encoding, which could turn line feeds into multiple bytes
+		err = w.send(w.encode(w.applyLineEnding(w.export())))
+	} else {
+		frame := w.formatBuffer()
+		w.recordFrame(frame)
+		err = w.write(w.screenBuffer(frame))
 	}
-	formattedBuffer := w.formatBuffer()
+	w.stats.RenderDuration = time.Since(start)
+	if w.statsHook != nil {
+		w.statsHook(w.stats)
+	}
+	w.emit(RenderFinished{Bytes: w.stats.Bytes, Duration: w.stats.RenderDuration})
+	return err
+}
+
+// Write retries allowed in a row when the output makes no progress, and the delay growing before each of them after
+// the first one
+const (
+	maxWriteRetries = 3
+	writeRetryDelay = 10 * time.Millisecond
+)
+
+// write sends the given formatted buffer to the [Writer]'s output, terminating its lines according to the selected
+// [LineEnding]
+func (w *Writer) write(formattedBuffer []byte) error {
+	return w.send(w.applyLineEnding(formattedBuffer))
+}
 
-	n, err := w.output.Write(formattedBuffer)
-	if err != nil || n != len(formattedBuffer) {
-		return io.ErrShortWrite
+// send sends the given bytes to the [Writer]'s output as they are. Partial writes are resumed with the remaining
+// bytes, while the writes failing with transient errors (e.g. EINTR or EAGAIN) or making no progress are retried a
+// few times before giving up
+func (w *Writer) send(formattedBuffer []byte) error {
+	retries := 0
+	for len(formattedBuffer) > 0 {
+		n, err := w.output.Write(formattedBuffer)
+		w.stats.Bytes += n
+		w.written += int64(n)
+		if w.cast != nil && n > 0 {
+			if castErr := w.cast.record(formattedBuffer[:n]); castErr != nil {
+				return errors.Join(err, castErr)
+			}
+		}
+		formattedBuffer = formattedBuffer[n:]
+		if n > 0 {
+			retries = 0
+		}
+		switch {
+		case err != nil && !errors.Is(err, io.ErrShortWrite) && !isTransient(err):
+			return err
+		case len(formattedBuffer) == 0:
+			return nil
+		case n > 0:
+			continue
+		case retries == maxWriteRetries:
+			return cmp.Or(err, io.ErrShortWrite)
+		}
+		retries++
+		w.debug("write retried", "remaining", len(formattedBuffer), "attempt", retries, "error", err)
+		time.Sleep(writeRetryDelay * time.Duration(retries-1))
 	}
 	return nil
 }
 
+// isTransient reports whether the given write error is temporary, so that the write can be retried
+func isTransient(err error) bool {
+	var temporary interface{ Temporary() bool }
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
+
 // Clear resets the state of the [Writer] to remove any traces of previously flushed content
 func (w *Writer) Clear() {
 	w.columns = make([]column, 0)
 	w.buffer = make([]byte, 0)
-	w.lines = make([]string, 0)
+	w.rows = make([][]cell, 0)
+	w.footnotes = make([]footnote, 0)
+	w.lazyRows = make(map[int][]any)
 }
 
-// init initializes the [Writer] by defining its initial configuration and state
-func (w *Writer) init(output io.Writer, flags uint) *Writer {
-	if flags&AsciiTable != 0 {
-		w.divider = dividers{
+// init initializes the [Writer] by defining its initial configuration and state.
+// Options are applied on top of the given flags, before the configuration is finalized
+func (w *Writer) init(output io.Writer, flags uint, opts ...Option) *Writer {
+	w.output = output
+	w.flags = flags
+	w.columnSpecs = make(map[int]ColumnSpec)
+	w.renames = make(map[string]string)
+	w.metadata = make(map[string]any)
+	w.presets = make(map[string]ResponsivePreset)
+	w.pseudonyms = make(map[string]map[string]int)
+	w.sanitizePolicy = make(map[string]SanitizeAction)
+	w.rowTemplates = make(map[string]*template.Template)
+	w.rowStyles = make(map[int]CellStyle)
+	w.cellStyles = make(map[[2]int]CellStyle)
+	w.SetEmojiWidth(EmojiWidthAuto)
+	w.SetAmbiguousWidth(AmbiguousWidthAuto)
+	for _, opt := range opts {
+		opt(w)
+	}
+	w.applyColorEnvironment()
+
+	w.initDividers()
+
+	w.measureTerminal()
+	w.Clear()
+	return w
+}
+
+// initDividers selects the dividers used to draw the table, according to the [Writer]'s configuration
+func (w *Writer) initDividers() {
+	if w.dividers != nil {
+		w.divider = *w.dividers
+	} else if w.flags&AsciiTable != 0 {
+		w.divider = Dividers{
 			HLine:  "-",
 			VLine:  "|",
 			TL:     "+", // Use '+' for corners/junctions
@@ -136,93 +381,175 @@ func (w *Writer) init(output io.Writer, flags uint) *Writer {
 			VLeft:  "+",
 			VRight: "+",
 		}
+	} else if divider, ok := styleDividers[w.style]; ok {
+		w.divider = divider
 	} else {
-		w.divider = dividers{
-			HLine:  "─",
-			VLine:  "│",
-			TL:     "┌",
-			TR:     "┐",
-			BL:     "└",
-			BR:     "┘",
-			TUp:    "┬",
-			TDown:  "┴",
-			Cross:  "┼",
-			VLeft:  "├",
-			VRight: "┤",
+		w.divider = styleDividers[StyleDefault]
+	}
+
+	if w.flags&CopyFriendly != 0 {
+		hLine := w.divider.HLine
+		w.divider = Dividers{
+			HLine:  hLine,
+			VLine:  " ",
+			TL:     hLine,
+			TR:     hLine,
+			BL:     hLine,
+			BR:     hLine,
+			TUp:    hLine,
+			TDown:  hLine,
+			Cross:  hLine,
+			VLeft:  hLine,
+			VRight: hLine,
 		}
 	}
 
-	w.termCols, _, _ = getTerminalSize(os.Stdout.Fd())
-	w.output = output
-	w.flags = flags
-	w.Clear()
-	return w
+	w.divider.OuterHLine = cmp.Or(w.divider.OuterHLine, w.divider.HLine)
+	w.divider.OuterVLine = cmp.Or(w.divider.OuterVLine, w.divider.VLine)
+	w.applyFrame()
+	w.overrideGlyphs()
+	w.applyBorders()
 }
 
-// truncateLongField cuts the last exceeding field and postpends a suffix indicating that the output has been truncated.
-// If the [PreserveLongFields] flag is set, the cut operation is not performed
-func (w *Writer) truncateLongField(l int, c int, maxFieldLen int, fields []string, colorlessFields [][]string) {
-	if w.flags&PreserveLongFields == 0 && w.termCols > 0 && c == len(fields)-1 && len(colorlessFields[l][c]) > maxFieldLen {
-		newColorLessStr := colorlessFields[l][c][:maxFieldLen-5] + "[...]"
-		newFieldStr := strings.Replace(fields[c], colorlessFields[l][c], colorlessFields[l][c][:maxFieldLen-5]+colorOrange+"[...]"+colorReset, -1)
-		w.lines[l] = strings.Replace(w.lines[l], fields[c], newFieldStr, -1)
-		colorlessFields[l][c] = newColorLessStr
+// splitRows splits the cleaned buffer into rows and fields. Empty lines are discarded
+// When [DropTrailingTab] is set, a single tab at the end of a line terminates the row instead of opening a new field
+func (w *Writer) splitRows(buffer string) [][]string {
+	rows := make([][]string, 0)
+	for _, line := range strings.Split(buffer, "\n") {
+		if w.flags&DropTrailingTab != 0 {
+			line = strings.TrimSuffix(line, "\t")
+		}
+		if len(line) != 0 {
+			rows = append(rows, strings.Split(line, "\t"))
+		}
+	}
+	return rows
+}
+
+// parseRows converts the given rows' fields into the cells used to render the table
+func (w *Writer) parseRows(rows [][]string, values [][]any) {
+	for r, fields := range rows {
+		kind := w.templateKind(values[r])
+		if values[r] != nil {
+			fields = w.resolveRow(values[r], true)
+		}
+		cells := make([]cell, len(fields), max(len(fields), 1))
+		if kind != "" && len(cells) == 0 {
+			cells = append(cells, cell{})
+		}
+		for c := range cells {
+			cells[c].kind = kind
+		}
+		for c, field := range fields {
+			field = strings.ReplaceAll(unescapeCell(field), "\t", " ")
+			if w.flags&InlineMarkup != 0 {
+				field = renderMarkup(field)
+			}
+			cells[c].plain = stripEscapeCodes(field)
+			if w.flags&StripColours != 0 {
+				cells[c].text = cells[c].plain
+			} else {
+				cells[c].text = field
+			}
+		}
+		w.rows = append(w.rows, cells)
 	}
 }
 
 // createColumns computes the total width of each field for each line and updates the column structure to keep track of
-// minimum required sizes
-func (w *Writer) createColumns() [][]string {
-	colorlessFields := make([][]string, len(w.lines))
-	for l, line := range w.lines {
-		if len(line) == 0 {
+// minimum required sizes. Fields exceeding their column's width budget are then processed according to the
+// column's [TruncatePolicy]
+func (w *Writer) createColumns() {
+	start := time.Now()
+	defer func() {
+		w.stats.LayoutDuration = time.Since(start)
+	}()
+	for _, cells := range w.rows {
+		// Rows rendered by templates fit the table, rather than shaping it
+		if w.isTemplateRow(cells) {
 			continue
 		}
-		fields := strings.Split(line, "\t")
-		maxFieldLen := w.termCols/len(fields) - 3
 		// Ensures there are enough columns for each field
-		if len(fields) > len(w.columns) {
-			w.columns = append(w.columns, make([]column, len(fields)-len(w.columns))...)
+		if len(cells) > len(w.columns) {
+			w.columns = append(w.columns, make([]column, len(cells)-len(w.columns))...)
 		}
 
 		// Computing maximum widths
-		colorlessFields[l] = make([]string, len(fields))
-		for c := range fields {
-			escapeColorCodes := escapeColorCodesRegex.FindAllString(fields[c], -1)
-			colorlessFields[l][c] = strings.Clone(fields[c])
-			for _, cc := range escapeColorCodes {
-				colorlessFields[l][c] = strings.Replace(colorlessFields[l][c], cc, "", -1)
+		for c := range cells {
+			if columnWidth := w.fieldWidth(cells[c].plain); columnWidth > w.columns[c].textWidth {
+				w.columns[c].textWidth = columnWidth
 			}
+		}
+	}
 
-			w.truncateLongField(l, c, maxFieldLen, fields, colorlessFields)
-			columnWidth := len(colorlessFields[l][c])
-			if columnWidth > w.columns[c].textWidth {
-				w.columns[c].textWidth = columnWidth
+	for c := range w.columns {
+		w.columns[c].numeric = w.isNumericColumn(c)
+		if w.columnSpec(c).Bar {
+			w.measureBar(c)
+		}
+		if maxWidth := w.columnSpec(c).MaxWidth; maxWidth > 0 {
+			w.columns[c].textWidth = min(w.columns[c].textWidth, maxWidth)
+		}
+		w.columns[c].hidden = w.isCollapsed(c)
+		if w.isResponsiveHidden(c) {
+			w.columns[c].hidden = true
+			w.debug("column dropped", "col", c, "terminal_cols", w.termCols, "reason", "responsive preset")
+			w.emit(ColumnDropped{Col: c})
+		}
+	}
+	w.spanTemplateRows()
+	w.debug("natural columns' widths computed", "widths", w.columnWidths(), "terminal_cols", w.termCols)
+	w.fitColumns()
+	w.lockColumns()
+	w.forceColumns()
+	w.stats.Rows = max(len(w.rows)-1, 0)
+	for c := range w.columns {
+		if !w.columns[c].hidden {
+			w.stats.Columns++
+		}
+	}
+	for r, cells := range w.rows {
+		for c := range cells {
+			if field, ok := w.barField(c, cells[c]); ok && r > 0 && !w.isTemplateRow(cells) {
+				cells[c].segments = closeEscapes([]string{field})
+				continue
+			}
+			cells[c].segments = closeEscapes(w.truncateField(c, cells[c]))
+			if width := w.fieldWidth(cells[c].plain); width > w.columns[c].textWidth && !w.columns[c].hidden {
+				w.stats.Truncations++
+				w.truncated[CellPosition{Row: r, Col: c}] = cells[c].text
+				w.emit(RowTruncated{Row: r, Col: c})
+				w.debug("field truncated", "row", r, "col", c, "width", width, "max_width", w.columns[c].textWidth,
+					"policy", w.columnSpec(c).Truncate, "lines", len(cells[c].segments))
 			}
 		}
 	}
-	return colorlessFields
+	w.widenWrappedColumns()
+	w.layout = w.columnWidths()
+	w.debug("columns' widths chosen", "widths", w.layout)
 }
 
 // getPadding determines the correct amount of spaces in order to correctly position and align each field inside its column
-func (w *Writer) getPadding(c int, colorlessField string) (int, []byte, []byte) {
-	totalPadding := w.columns[c].textWidth - len(colorlessField)
+func (w *Writer) getPadding(c int, fieldWidth int) (int, []byte, []byte) {
+	// Fields wider than their column are never expected, but they must not result in a negative padding
+	totalPadding := max(w.columns[c].textWidth-fieldWidth, 0)
 	if w.flags&RemoveLeastPad == 0 {
 		totalPadding += 1
 	}
 	var leftPaddingStr []byte
 	var rightPaddingStr []byte
 	if w.flags&PreserveLongFields == 0 {
-		if w.flags&AlignMiddle != 0 {
+		switch w.columnAlignment(c) {
+		case Center:
 			if w.flags&RemoveLeastPad == 0 {
 				totalPadding += 1
 			}
 			halfPadding := totalPadding / 2
 			leftPaddingStr = bytes.Repeat([]byte{' '}, halfPadding)
 			rightPaddingStr = bytes.Repeat([]byte{' '}, totalPadding-halfPadding)
-		} else if w.flags&AlignRight != 0 {
+		case Right, Decimal:
 			leftPaddingStr = bytes.Repeat([]byte{' '}, totalPadding)
-		} else {
+		default:
 			rightPaddingStr = bytes.Repeat([]byte{' '}, totalPadding)
 		}
 	} else {
@@ -233,95 +560,214 @@ func (w *Writer) getPadding(c int, colorlessField string) (int, []byte, []byte)
 	return totalPadding, leftPaddingStr, rightPaddingStr
 }
 
-// updateHLine computes the length of the horizontal divider line and appends new dividers to it based on the currently
-// available space in the terminal
-func (w *Writer) updateHLine(hLine *string, hLineLength int, l int, isLastRow bool, isLastField bool) {
+// fillPadding replaces the spaces aligning a data field within the column at the given index with the column's
+// padding characters, keeping the spaces that separate the field from the borders
+func (w *Writer) fillPadding(c int, leftPadding []byte, rightPadding []byte) ([]byte, []byte) {
+	spec := w.columnSpec(c)
+	kept := 0
+	if w.flags&RemoveLeastPad == 0 {
+		kept = 1
+	}
+	if char := string(spec.PadLeft); spec.PadLeft != 0 && w.stringWidth(char) == 1 && len(leftPadding) > kept {
+		leftPadding = append(bytes.Repeat([]byte{' '}, kept), strings.Repeat(char, len(leftPadding)-kept)...)
+	}
+	if char := string(spec.PadRight); spec.PadRight != 0 && w.stringWidth(char) == 1 && len(rightPadding) > kept {
+		rightPadding = append([]byte(strings.Repeat(char, len(rightPadding)-kept)), bytes.Repeat([]byte{' '}, kept)...)
+	}
+	return leftPadding, rightPadding
+}
+
+// updateHLine computes the length of the horizontal divider line and appends new dividers to it, up to the available
+// space in the terminal when long fields are preserved
+func (w *Writer) updateHLine(d *Dividers, hLine *string, hLineLength int, l int, isLastRow bool, isLastField bool) {
 	// Unicode divider might consist into multiple bytes, but represent only 1 visual character
-	// In order to always compute the visual hLine, we must divide its length by the number of bytes used by a divider
-	// This only works because hLine is only made up from the same repeated divider types (all 3 bytes for box lines)
+	// In order to always compute the visual hLine, we must count its runes instead of its bytes
+	// This only works because every divider is made up from a single character
+	// The table's frame might use different dividers than the inner lines
+	hDivider := d.HLine
+	if l == 0 || isLastRow {
+		hDivider = d.OuterHLine
+	}
 	var xDivider string
 	switch {
 	case l == 0 && !isLastField:
-		xDivider = w.divider.TUp
+		xDivider = d.TUp
 	case l == 0:
-		xDivider = w.divider.TR
+		xDivider = d.TR
 	case isLastRow && !isLastField:
-		xDivider = w.divider.TDown
+		xDivider = d.TDown
 	case isLastRow:
-		xDivider = w.divider.BR
+		xDivider = d.BR
 	case isLastField:
-		xDivider = w.divider.VRight
+		xDivider = d.VRight
 	default:
-		xDivider = w.divider.Cross
+		xDivider = d.Cross
 	}
 
 	// Adding a prefix to the hLine to render the first column's left border
 	if isLastField {
 		if l == 0 {
-			*hLine = w.divider.TL + *hLine
+			*hLine = d.TL + *hLine
 		} else if isLastRow {
-			*hLine = w.divider.BL + *hLine
+			*hLine = d.BL + *hLine
 		} else {
-			*hLine = w.divider.VLeft + *hLine
+			*hLine = d.VLeft + *hLine
 		}
 	}
-	availableTermSpace := w.termCols - (len(*hLine) / len(w.divider.HLine))
+	// Lines are only cut at the terminal's width when long fields are preserved, since their rows wrap in the terminal.
+	// Other tables are as wide as their rows, even when overflowing tiny or unknown terminals
+	availableTermSpace := hLineLength
+	if w.flags&PreserveLongFields != 0 && w.termCols > 0 {
+		availableTermSpace = w.termCols - utf8.RuneCountInString(*hLine)
+	}
 	if availableTermSpace > 0 {
 		if availableTermSpace >= hLineLength {
-			*hLine += strings.Repeat(w.divider.HLine, hLineLength-1) + xDivider
+			*hLine += strings.Repeat(hDivider, hLineLength-1) + xDivider
 		} else {
-			*hLine += strings.Repeat(w.divider.HLine, availableTermSpace-1) + xDivider
+			*hLine += strings.Repeat(hDivider, availableTermSpace-1) + xDivider
 		}
 	}
 }
 
-// createTable transforms the [Writer]'s internal buffer data into a styled and formatted table
-func (w *Writer) createTable(colorlessFields [][]string) []byte {
-	formattedBuffer := make([]byte, 0)
-	for l, line := range w.lines {
-		if len(line) == 0 {
-			continue
+// visibleColumns returns the indexes of the given row's fields that belong to columns which are not hidden
+func (w *Writer) visibleColumns(cells []cell) []int {
+	visible := make([]int, 0, len(cells))
+	for c := range cells {
+		if !w.columns[c].hidden {
+			visible = append(visible, c)
 		}
-		fields := strings.Split(line, "\t")
+	}
+	return visible
+}
 
-		// Writing to the output
-		hLine := ""
-		prefixHLine := ""
-		for c, field := range fields {
-			if w.flags&StripColours != 0 {
-				field = colorlessFields[l][c]
-			}
+// renderRow renders the physical lines of the given row, one for each line spanned by its fields.
+// The header's fields are always padded with spaces
+func (w *Writer) renderRow(cells []cell, visible []int, header bool) []byte {
+	if rowBuffer, ok := w.renderTemplateRow(cells, visible); ok {
+		return rowBuffer
+	}
+	// Computing the number of physical lines required by the row
+	height := 1
+	for _, c := range visible {
+		height = max(height, len(cells[c].segments))
+	}
 
-			totalPadding, leftPaddingStr, rightPaddingStr := w.getPadding(c, colorlessFields[l][c])
-			// Used to render the first column's left border segments
-			if c == 0 {
-				formattedBuffer = append(append(append(append(append(formattedBuffer, w.divider.VLine...), leftPaddingStr...), field...), rightPaddingStr...), w.divider.VLine...)
-			} else {
-				formattedBuffer = append(append(append(append(formattedBuffer, leftPaddingStr...), field...), rightPaddingStr...), w.divider.VLine...)
+	rowBuffer := make([]byte, 0)
+	for i := 0; i < height; i++ {
+		// Used to render the first column's left border segments
+		rowBuffer = append(rowBuffer, w.colorBorder(w.divider.OuterVLine)...)
+		for f, c := range visible {
+			segment := ""
+			if i < len(cells[c].segments) {
+				segment = cells[c].segments[i]
+			}
+			if i == 0 {
+				rowBuffer = append(rowBuffer, cells[c].prefix...)
 			}
-			hLineLength := len(colorlessFields[l][c]) + totalPadding + 1
-			// Necessary to add a top border to the table header or first row
-			if l == 0 {
-				w.updateHLine(&prefixHLine, hLineLength, l, l == len(w.lines)-1, c == len(fields)-1)
+			vDivider := w.divider.VLine
+			if f == len(visible)-1 {
+				vDivider = w.divider.OuterVLine
 			}
-			w.updateHLine(&hLine, hLineLength, l+1, l == len(w.lines)-1, c == len(fields)-1)
+			_, leftPaddingStr, rightPaddingStr := w.getPadding(c, w.stringWidth(stripEscapeCodes(segment)))
+			if !header {
+				leftPaddingStr, rightPaddingStr = w.fillPadding(c, leftPaddingStr, rightPaddingStr)
+			}
+			rowBuffer = append(append(append(append(rowBuffer, leftPaddingStr...), segment...), rightPaddingStr...), w.colorBorder(vDivider)...)
+		}
+		if w.divider.OuterVLine == "" {
+			// Without a right border, the last column's padding would only leave trailing spaces
+			rowBuffer = bytes.TrimRight(rowBuffer, " ")
+		}
+		rowBuffer = append(rowBuffer, '\n')
+	}
+	return rowBuffer
+}
 
+// rowHLine builds the horizontal line drawn under the given row, or above it when l is 0, using the given dividers
+func (w *Writer) rowHLine(d *Dividers, cells []cell, visible []int, l int, isLastRow bool) []byte {
+	if !w.drawsHLine(l, isLastRow) {
+		return nil
+	}
+	hLine := ""
+	for f, c := range visible {
+		fieldWidth := 0
+		for _, segment := range cells[c].segments {
+			fieldWidth = max(fieldWidth, w.stringWidth(stripEscapeCodes(segment)))
 		}
+		totalPadding, _, _ := w.getPadding(c, fieldWidth)
+		w.updateHLine(d, &hLine, fieldWidth+totalPadding+1, l, isLastRow, f == len(visible)-1)
+	}
+	return append([]byte(w.colorBorder(hLine)), '\n')
+}
+
+// createTable transforms the [Writer]'s internal buffer data into a styled and formatted table
+func (w *Writer) createTable() []byte {
+	formattedBuffer := make([]byte, 0)
+	following := w.flags&FollowMode != 0
+	for l, cells := range w.rows {
+		visible := w.visibleColumns(cells)
+		// Tables in follow mode are never closed, since more rows can be appended by the next flushes
+		isLastRow := l == len(w.rows)-1 && !following
+
 		// Necessary to add a top border to the table header or first row
 		if l == 0 {
-			formattedBuffer = append([]byte(prefixHLine+"\n"), formattedBuffer...)
-			prefixHLine = ""
+			if w.isFollowing() {
+				continue
+			}
+			formattedBuffer = append(formattedBuffer, w.rowHLine(&w.divider, cells, visible, l, isLastRow)...)
+		}
+		rowBuffer := w.renderRow(cells, visible, l == 0)
+		var separator []byte
+		if !w.isGuideRow(l+w.follow.rows, isLastRow) {
+			separator = w.rowHLine(&w.divider, cells, visible, l+1, isLastRow)
+		} else if w.guideMode == GuideHeader {
+			// Guides are drawn after every N data rows, either as a thicker separator or by repeating the header
+			separator = append(w.rowHLine(&w.divider, cells, visible, l+1, isLastRow), w.headerBlock()...)
+		} else {
+			guide := w.guideDividers()
+			separator = w.rowHLine(&guide, cells, visible, l+1, isLastRow)
+		}
+		if following {
+			formattedBuffer = append(formattedBuffer, w.followRow(l, rowBuffer, separator)...)
+		} else {
+			formattedBuffer = append(append(formattedBuffer, rowBuffer...), separator...)
 		}
-		formattedBuffer = append(formattedBuffer, '\n')
-		formattedBuffer = append(formattedBuffer, hLine...)
-		formattedBuffer = append(formattedBuffer, '\n')
 	}
 	return formattedBuffer
 }
 
+// headerBlock renders the header row followed by its separator, in order to repeat it in the middle of the table
+func (w *Writer) headerBlock() []byte {
+	header := w.rows[0]
+	visible := w.visibleColumns(header)
+	return append(w.renderRow(header, visible, true), w.rowHLine(&w.divider, header, visible, 1, false)...)
+}
+
 // formatBuffer processes the [Writer]'s buffered data, restyles it and generates a formatted output string that
 // can be sent to the final [io.Writer]
 func (w *Writer) formatBuffer() []byte {
-	colorlessFields := w.createColumns()
-	return w.createTable(colorlessFields)
+	if w.isFollowing() {
+		w.rows = append([][]cell{w.follow.header}, w.rows...)
+	} else {
+		w.renderHeader()
+		w.annotateHeader()
+	}
+	w.anonymizeFields()
+	copyValues := w.copyValues()
+	w.compareRows()
+	w.annotateColumns()
+	w.formatPercentages()
+	w.foldPrefixes()
+	w.suppressDittos()
+	copyList := w.markCopyValues(copyValues)
+	footnotes := w.markFootnotes()
+	w.alignAnchors()
+	w.collapseGroups()
+	w.applyCellStyles()
+	w.createColumns()
+	table := w.alignTable(w.shadowTable(w.createTable()))
+	w.updateFollowState()
+	table = append(table, w.renderSummaryLine()...)
+	table = append(table, w.renderLegend()...)
+	return append(append(table, copyList...), footnotes...)
 }
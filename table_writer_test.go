@@ -0,0 +1,288 @@
+package TableWriter
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"testing"
+)
+
+// renderTable renders the given tab-separated input with the given flags and options, failing the test if the
+// table cannot be rendered
+func renderTable(t *testing.T, input string, flags uint, opts ...Option) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w := NewWriter(&buf, flags, opts...)
+	if _, err := io.WriteString(w, input); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestRenderMalformedInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "empty input", input: "", want: ""},
+		{name: "blank lines only", input: "\n\n", want: ""},
+		{
+			name:  "row shorter than the header",
+			input: "a\tb\n1\n",
+			want: "┌──┬──┐\n" +
+				"│a │b │\n" +
+				"├──┼──┤\n" +
+				"│1 │\n" +
+				"└──┘\n",
+		},
+		{
+			name:  "row longer than the header",
+			input: "a\n1\t2\t3\n",
+			want: "┌──┐\n" +
+				"│a │\n" +
+				"├──┤\n" +
+				"│1 │2 │3 │\n" +
+				"└──┴──┴──┘\n",
+		},
+		{
+			name:  "empty fields only",
+			input: "\t\t\n",
+			want: "┌─┬─┬─┐\n" +
+				"│ │ │ │\n" +
+				"└─┴─┴─┘\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderTable(t, tt.input, StripColours, WithWidth(40)); got != tt.want {
+				t.Errorf("rendered table =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderRecoversFromPanics(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, StripColours, WithWidth(40))
+	_, _ = io.WriteString(w, "a\n")
+	_ = w.AppendRow(func() string { panic("boom") })
+	if err := w.Flush(); !errors.Is(err, ErrRender) {
+		t.Fatalf("Flush() error = %v, want %v", err, ErrRender)
+	}
+
+	// The buffer is cleared, so that the next table is rendered from scratch
+	buf.Reset()
+	_, _ = io.WriteString(w, "b\n")
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if want := "┌──┐\n│b │\n└──┘\n"; buf.String() != want {
+		t.Errorf("rendered table =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestRenderTinyTerminals(t *testing.T) {
+	// Columns are never shrunk below 3 cells, so the table overflows terminals narrower than it, keeping its
+	// borders aligned with its rows
+	narrow := "┌────┬───┐\n" +
+		"│na… │id │\n" +
+		"├────┼───┤\n" +
+		"│al… │1  │\n" +
+		"└────┴───┘\n"
+	tests := []struct {
+		name  string
+		width int
+		want  string
+	}{
+		{
+			name:  "unknown width",
+			width: 0,
+			want: "┌──────┬───┐\n" +
+				"│name  │id │\n" +
+				"├──────┼───┤\n" +
+				"│alice │1  │\n" +
+				"└──────┴───┘\n",
+		},
+		{name: "single column terminal", width: 1, want: narrow},
+		{name: "terminal narrower than the borders", width: 3, want: narrow},
+		{name: "terminal narrower than the table", width: 9, want: narrow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Files are measured directly, so that the terminal running the tests, if any, is ignored
+			t.Setenv("COLUMNS", "")
+			output, err := os.CreateTemp(t.TempDir(), "table")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer output.Close()
+			w := NewWriter(output, StripColours, WithWidth(tt.width))
+			_, _ = io.WriteString(w, "name\tid\nalice\t1\n")
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got, _ := os.ReadFile(output.Name()); string(got) != tt.want {
+				t.Errorf("rendered table =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// temporaryError is a transient write error, like EINTR or EAGAIN
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "resource temporarily unavailable" }
+func (temporaryError) Temporary() bool { return true }
+
+// flakyWriter fails its first writes with the given error, or all of them when failures is negative, then accepts at
+// most chunk bytes per write, if set
+type flakyWriter struct {
+	bytes.Buffer
+	failures int
+	err      error
+	chunk    int
+	calls    int
+}
+
+func (f *flakyWriter) Write(p []byte) (int, error) {
+	f.calls++
+	if f.failures != 0 {
+		f.failures--
+		return 0, f.err
+	}
+	if f.chunk > 0 && len(p) > f.chunk {
+		return f.Buffer.Write(p[:f.chunk])
+	}
+	return f.Buffer.Write(p)
+}
+
+func TestFlushRetriesWrites(t *testing.T) {
+	const input = "name\tid\nalice\t1\n"
+	table := renderTable(t, input, StripColours, WithWidth(40))
+	permanent := errors.New("broken pipe")
+	tests := []struct {
+		name      string
+		output    *flakyWriter
+		wantCalls int
+		wantErr   error
+		wantTable string
+	}{
+		{
+			name:      "transient errors",
+			output:    &flakyWriter{failures: 2, err: temporaryError{}},
+			wantCalls: 3,
+			wantTable: table,
+		},
+		{
+			name:      "short writes",
+			output:    &flakyWriter{chunk: 16},
+			wantCalls: (len(table) + 15) / 16,
+			wantTable: table,
+		},
+		{
+			name:      "short write errors",
+			output:    &flakyWriter{failures: 1, err: io.ErrShortWrite, chunk: len(table) - 1},
+			wantCalls: 3,
+			wantTable: table,
+		},
+		{
+			name:      "permanent error",
+			output:    &flakyWriter{failures: 1, err: permanent},
+			wantCalls: 1,
+			wantErr:   permanent,
+		},
+		{
+			name:      "persistent transient errors",
+			output:    &flakyWriter{failures: -1, err: temporaryError{}},
+			wantCalls: maxWriteRetries + 1,
+			wantErr:   temporaryError{},
+		},
+		{
+			name:      "no progress",
+			output:    &flakyWriter{failures: -1},
+			wantCalls: maxWriteRetries + 1,
+			wantErr:   io.ErrShortWrite,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWriter(tt.output, StripColours, WithWidth(40))
+			_, _ = io.WriteString(w, input)
+			if err := w.Flush(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Flush() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.output.calls != tt.wantCalls {
+				t.Errorf("writes = %d, want %d", tt.output.calls, tt.wantCalls)
+			}
+			if got := tt.output.String(); got != tt.wantTable {
+				t.Errorf("written table =\n%s\nwant\n%s", got, tt.wantTable)
+			}
+			if got := w.BytesWritten(); got != int64(len(tt.wantTable)) {
+				t.Errorf("BytesWritten() = %d, want %d", got, len(tt.wantTable))
+			}
+		})
+	}
+}
+
+func TestTrailingTab(t *testing.T) {
+	const input = "a\tb\t\n1\t2\t\n3\t4\n"
+	tests := []struct {
+		name      string
+		flags     uint
+		wantRows  [][]string
+		wantTable string
+	}{
+		{
+			name:     "empty last field",
+			wantRows: [][]string{{"a", "b", ""}, {"1", "2", ""}, {"3", "4"}},
+			wantTable: "┌──┬──┬─┐\n" +
+				"│a │b │ │\n" +
+				"├──┼──┼─┤\n" +
+				"│1 │2 │ │\n" +
+				"├──┼──┼─┤\n" +
+				"│3 │4 │\n" +
+				"└──┴──┘\n",
+		},
+		{
+			name:     "dropped",
+			flags:    DropTrailingTab,
+			wantRows: [][]string{{"a", "b"}, {"1", "2"}, {"3", "4"}},
+			wantTable: "┌──┬──┐\n" +
+				"│a │b │\n" +
+				"├──┼──┤\n" +
+				"│1 │2 │\n" +
+				"├──┼──┤\n" +
+				"│3 │4 │\n" +
+				"└──┴──┘\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWriter(io.Discard, tt.flags)
+			if got := w.splitRows(input); !slices.EqualFunc(got, tt.wantRows, slices.Equal) {
+				t.Errorf("splitRows() = %q, want %q", got, tt.wantRows)
+			}
+			if got := renderTable(t, input, tt.flags|StripColours, WithWidth(40)); got != tt.wantTable {
+				t.Errorf("rendered table =\n%s\nwant\n%s", got, tt.wantTable)
+			}
+		})
+	}
+}
+
+func TestTrailingTabOption(t *testing.T) {
+	opts, err := OptionsFromArgs([]string{"--drop-trailing-tab"})
+	if err != nil {
+		t.Fatalf("OptionsFromArgs() error = %v", err)
+	}
+	w := NewWriter(io.Discard, 0, opts...)
+	_, _ = io.WriteString(w, "a\tb\t\n1\t2\t\n")
+	if m := w.Model(); !slices.Equal(m.Header, []string{"a", "b"}) || !slices.Equal(m.Rows[0], []string{"1", "2"}) {
+		t.Errorf("Model() = %q %q, want the trailing tabs dropped", m.Header, m.Rows)
+	}
+}
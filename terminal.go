@@ -0,0 +1,102 @@
+package TableWriter
+
+import (
+	"cmp"
+	"os"
+	"strconv"
+)
+
+// terminalFd returns the file descriptor used to measure the terminal. Outputs exposing their own descriptor, like
+// [os.File], are measured directly, so that tables written to stderr or to another terminal fit it. Any other output
+// is assumed to be eventually displayed on the standard output
+func (w *Writer) terminalFd() uintptr {
+	if file, ok := w.output.(interface{ Fd() uintptr }); ok {
+		return file.Fd()
+	}
+	return os.Stdout.Fd()
+}
+
+// isTerminal reports whether the output is a terminal. Outputs that do not expose their file descriptor, like
+// buffers and network connections, are never considered terminals
+func (w *Writer) isTerminal() bool {
+	file, ok := w.output.(interface{ Fd() uintptr })
+	if !ok {
+		return false
+	}
+	_, _, err := getTerminalSize(file.Fd())
+	return err == nil
+}
+
+// measureTerminal retrieves the terminal's size. When the output is not a terminal (e.g. in CI, pipes or
+// containers), the controlling terminal is measured if [TTYFallback] is set, otherwise the size is read from the
+// COLUMNS and LINES environment variables, while the default width set with [Writer.SetDefaultWidth] is used as a
+// last resort
+func (w *Writer) measureTerminal() {
+	defer func() {
+		// Sizes forced with SetWidth and SetHeight always take precedence
+		w.termCols = cmp.Or(w.width, w.termCols)
+		w.termRows = cmp.Or(w.height, w.termRows)
+	}()
+	var err error
+	if w.termCols, w.termRows, err = getTerminalSize(w.terminalFd()); err == nil && w.termCols > 0 {
+		return
+	}
+	if w.flags&TTYFallback != 0 {
+		if cols, rows, ttyErr := getControllingTerminalSize(); ttyErr == nil && cols > 0 {
+			w.termCols, w.termRows = cols, rows
+			w.debug("output is not a terminal, using the controlling terminal's size", "terminal_cols", cols,
+				"terminal_rows", rows)
+			return
+		}
+	}
+	w.termCols, _ = strconv.Atoi(os.Getenv("COLUMNS"))
+	w.termRows, _ = strconv.Atoi(os.Getenv("LINES"))
+	if w.termCols <= 0 {
+		w.termCols = w.defaultWidth
+	}
+	w.debug("terminal size unavailable, using fallbacks", "error", err, "terminal_cols", w.termCols,
+		"terminal_rows", w.termRows)
+}
+
+// SetDefaultWidth sets the width used to lay out the table when neither the terminal nor the COLUMNS environment
+// variable report it. Zero, the default, means unlimited: the table is drawn at its natural width, without
+// truncating long fields
+func (w *Writer) SetDefaultWidth(cols int) {
+	w.defaultWidth = max(cols, 0)
+	w.measureTerminal()
+}
+
+// WithDefaultWidth sets the width used when the terminal's one is unknown. See [Writer.SetDefaultWidth]
+func WithDefaultWidth(cols int) Option {
+	return func(w *Writer) {
+		w.defaultWidth = max(cols, 0)
+	}
+}
+
+// SetWidth forces the width used to lay out the table, regardless of the terminal's one. Useful to render tables
+// into log files or documents, where there is no terminal. Zero restores the detected width
+func (w *Writer) SetWidth(cols int) {
+	w.width = max(cols, 0)
+	w.measureTerminal()
+}
+
+// WithWidth forces the width used to lay out the table. See [Writer.SetWidth]
+func WithWidth(cols int) Option {
+	return func(w *Writer) {
+		w.width = max(cols, 0)
+	}
+}
+
+// SetHeight forces the height of the terminal, used to repeat the header in [FollowMode], regardless of the
+// terminal's one. Zero restores the detected height
+func (w *Writer) SetHeight(rows int) {
+	w.height = max(rows, 0)
+	w.measureTerminal()
+}
+
+// WithHeight forces the height of the terminal. See [Writer.SetHeight]
+func WithHeight(rows int) Option {
+	return func(w *Writer) {
+		w.height = max(rows, 0)
+	}
+}
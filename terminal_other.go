@@ -0,0 +1,18 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+
+package TableWriter
+
+import "errors"
+
+// getTerminalSize always fails, since the terminal's size cannot be retrieved on this platform
+func getTerminalSize(fd uintptr) (cols, rows int, err error) {
+	return 0, 0, errors.New("terminal size not supported on this platform")
+}
+
+// getControllingTerminalSize always fails, since the terminal's size cannot be retrieved on this platform
+func getControllingTerminalSize() (cols, rows int, err error) {
+	return getTerminalSize(0)
+}
+
+// watchResize does nothing, since terminal resizes cannot be detected on this platform
+func (w *Writer) watchResize() {}
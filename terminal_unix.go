@@ -0,0 +1,54 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package TableWriter
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+// Winsize is the structure used for ioctl calls, to obtain the terminal size.
+type winsize struct {
+	Row    uint16 // Rows number
+	Col    uint16 // Columns number (width)
+	Xpixel uint16 // Pixel's width
+	Ypixel uint16 // Pixel's Height
+}
+
+// getTerminalSize retrieves the terminal's size associated to the given file descriptor
+func getTerminalSize(fd uintptr) (cols, rows int, err error) {
+	ws := &winsize{}
+
+	// TIOCGWINSZ is the constant that tells the kernel to retrieve the TTY size.
+	// Using the TIOCGWINSZ syscall is tailored to Linux/macOS.
+	ret, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		fd,
+		uintptr(syscall.TIOCGWINSZ),
+		uintptr(unsafe.Pointer(ws)),
+	)
+
+	if int(ret) == -1 {
+		return 0, 0, errno
+	}
+	return int(ws.Col), int(ws.Row), nil
+}
+
+// getControllingTerminalSize retrieves the size of the process's controlling terminal, if any, regardless of the
+// redirection of its standard streams
+func getControllingTerminalSize() (cols, rows int, err error) {
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tty.Close()
+	return getTerminalSize(tty.Fd())
+}
+
+// watchResize starts listening for the terminal's resize signals (SIGWINCH), so that live tables adapt their layout
+func (w *Writer) watchResize() {
+	w.resize = make(chan os.Signal, 1)
+	signal.Notify(w.resize, syscall.SIGWINCH)
+}
@@ -0,0 +1,58 @@
+//go:build windows
+
+package TableWriter
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var procGetConsoleScreenBufferInfo = syscall.NewLazyDLL("kernel32.dll").NewProc("GetConsoleScreenBufferInfo")
+
+// coord and smallRect are the structures used by the Windows console API to describe positions and areas
+type coord struct {
+	X int16
+	Y int16
+}
+
+type smallRect struct {
+	Left   int16
+	Top    int16
+	Right  int16
+	Bottom int16
+}
+
+// consoleScreenBufferInfo is the structure filled by GetConsoleScreenBufferInfo
+type consoleScreenBufferInfo struct {
+	Size              coord
+	CursorPosition    coord
+	Attributes        uint16
+	Window            smallRect // Visible area of the screen buffer
+	MaximumWindowSize coord
+}
+
+// getTerminalSize retrieves the size of the console window associated to the given handle.
+// The visible window is measured, rather than the whole screen buffer, which includes the scrollback
+func getTerminalSize(fd uintptr) (cols, rows int, err error) {
+	var info consoleScreenBufferInfo
+	ret, _, err := procGetConsoleScreenBufferInfo.Call(fd, uintptr(unsafe.Pointer(&info)))
+	if ret == 0 {
+		return 0, 0, err
+	}
+	return int(info.Window.Right-info.Window.Left) + 1, int(info.Window.Bottom-info.Window.Top) + 1, nil
+}
+
+// getControllingTerminalSize retrieves the size of the console attached to the process, if any, regardless of the
+// redirection of its standard streams
+func getControllingTerminalSize() (cols, rows int, err error) {
+	console, err := os.OpenFile("CONOUT$", os.O_RDWR, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer console.Close()
+	return getTerminalSize(console.Fd())
+}
+
+// watchResize does nothing, since Windows consoles do not signal their resizes
+func (w *Writer) watchResize() {}
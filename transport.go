@@ -0,0 +1,355 @@
+package TableWriter
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Wire types of the protobuf encoding used by [Model.MarshalBinary]. See https://protobuf.dev/programming-guides/encoding
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+// errInvalidMessage is returned when decoding a malformed table message
+var errInvalidMessage = errors.New("invalid table message")
+
+// sgr returns the SGR parameters setting the color, where base is 30 for foreground colors and 40 for background
+// ones, or an empty string for the default color. The standard colors use their own parameters, which are supported
+// by any terminal
+func (c sgrColor) sgr(base int) string {
+	switch {
+	case !c.set:
+		return ""
+	case c.rgb:
+		return fmt.Sprintf("%d;2;%d;%d;%d", base+8, c.value>>16&0xff, c.value>>8&0xff, c.value&0xff)
+	case c.value < 8:
+		return strconv.Itoa(base + int(c.value))
+	case c.value < 16:
+		return strconv.Itoa(base + 60 + int(c.value) - 8)
+	default:
+		return fmt.Sprintf("%d;5;%d", base+8, c.value)
+	}
+}
+
+// sgr returns the SGR sequence applying the style over the terminal's default one, or an empty string for the
+// default style
+func (s sgrStyle) sgr() string {
+	params := make([]string, 0)
+	for _, attribute := range []struct {
+		set  bool
+		code string
+	}{{s.bold, "1"}, {s.dim, "2"}, {s.italic, "3"}, {s.underline, "4"}, {s.inverse, "7"}, {s.strike, "9"}} {
+		if attribute.set {
+			params = append(params, attribute.code)
+		}
+	}
+	for _, color := range []string{s.fg.sgr(30), s.bg.sgr(40)} {
+		if color != "" {
+			params = append(params, color)
+		}
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// appendProtoVarint appends a varint field to the given message
+func appendProtoVarint(b []byte, field int, v uint64) []byte {
+	return binary.AppendUvarint(binary.AppendUvarint(b, uint64(field<<3|protoVarint)), v)
+}
+
+// appendProtoBytes appends a length-delimited field, holding a string or a nested message, to the given message
+func appendProtoBytes(b []byte, field int, content []byte) []byte {
+	b = binary.AppendUvarint(binary.AppendUvarint(b, uint64(field<<3|protoBytes)), uint64(len(content)))
+	return append(b, content...)
+}
+
+// protoFields calls field for each of the fields of the given message, with their number, their wire type and their
+// value: the integer held by varint and fixed-size fields, or the content of length-delimited ones.
+// It stops at the first error returned by field, or with errInvalidMessage when the message is malformed
+func protoFields(data []byte, field func(n int, wire int, v uint64, content []byte) error) error {
+	for len(data) > 0 {
+		key, size := binary.Uvarint(data)
+		if size <= 0 || key>>3 == 0 || key>>3 > math.MaxInt32 {
+			return errInvalidMessage
+		}
+		data = data[size:]
+		var v uint64
+		var content []byte
+		switch key & 7 {
+		case protoVarint:
+			if v, size = binary.Uvarint(data); size <= 0 {
+				return errInvalidMessage
+			}
+			data = data[size:]
+		case protoFixed64:
+			if len(data) < 8 {
+				return errInvalidMessage
+			}
+			v, data = binary.LittleEndian.Uint64(data), data[8:]
+		case protoFixed32:
+			if len(data) < 4 {
+				return errInvalidMessage
+			}
+			v, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+		case protoBytes:
+			length, size := binary.Uvarint(data)
+			if size <= 0 || length > uint64(len(data)-size) {
+				return errInvalidMessage
+			}
+			content, data = data[size:size+int(length)], data[size+int(length):]
+		default:
+			return errInvalidMessage
+		}
+		if err := field(int(key>>3), int(key&7), v, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalBinary encodes the model as the protobuf Table message defined by tablewriter.proto, so that agents can send
+// their tables to a central renderer, which draws them according to its own terminal's capabilities rather than
+// receiving pre-rendered ANSI text. The fields' SGR styles and hyperlinks are described by the message's attributes,
+// while any other escape sequence is dropped. Numeric and boolean fields also carry their typed value.
+// It implements the [encoding.BinaryMarshaler] interface
+func (m *Model) MarshalBinary() ([]byte, error) {
+	var table []byte
+	if m.Header != nil {
+		table = appendProtoBytes(table, 1, marshalRow(m.Header))
+	}
+	for _, row := range m.Rows {
+		table = appendProtoBytes(table, 2, marshalRow(row))
+	}
+	return table, nil
+}
+
+// marshalRow encodes the given row as a Row message
+func marshalRow(row []string) []byte {
+	var b []byte
+	for _, field := range row {
+		b = appendProtoBytes(b, 1, marshalCell(field))
+	}
+	return b
+}
+
+// marshalCell encodes the given field as a Cell message, splitting its text into spans whenever its style or its
+// hyperlink change
+func marshalCell(field string) []byte {
+	var cell []byte
+	var style sgrStyle
+	link := ""
+	for segment, escape := range escapeSegments(field) {
+		switch {
+		case escape && strings.HasPrefix(segment, "\x1b[") && strings.HasSuffix(segment, "m"):
+			style.apply(segment)
+		case escape && strings.HasPrefix(segment, "\x1b]8;"):
+			link = hyperlinkTarget(segment)
+		case !escape:
+			span := appendProtoBytes(nil, 1, []byte(segment))
+			if style != (sgrStyle{}) {
+				span = appendProtoBytes(span, 2, marshalStyle(style))
+			}
+			if link != "" {
+				span = appendProtoBytes(span, 3, []byte(link))
+			}
+			cell = appendProtoBytes(cell, 1, span)
+		}
+	}
+
+	plain := stripEscapeCodes(field)
+	switch valueType(plain) {
+	case TypeInteger:
+		n, _ := strconv.ParseInt(plain, 10, 64)
+		cell = binary.AppendVarint(binary.AppendUvarint(cell, 2<<3|protoVarint), n)
+	case TypeFloat:
+		n, _ := strconv.ParseFloat(plain, 64)
+		cell = binary.AppendUvarint(cell, 3<<3|protoFixed64)
+		cell = binary.LittleEndian.AppendUint64(cell, math.Float64bits(n))
+	case TypeBool:
+		value := uint64(0)
+		if b, _ := parseBool(plain); b {
+			value = 1
+		}
+		cell = appendProtoVarint(cell, 4, value)
+	}
+	return cell
+}
+
+// marshalStyle encodes the given style as a Style message
+func marshalStyle(s sgrStyle) []byte {
+	var b []byte
+	for field, color := range []sgrColor{s.fg, s.bg} {
+		switch {
+		case color.rgb:
+			b = appendProtoBytes(b, field+1, appendProtoVarint(nil, 2, uint64(color.value)))
+		case color.set:
+			b = appendProtoBytes(b, field+1, appendProtoVarint(nil, 1, uint64(color.value)))
+		}
+	}
+	for i, attribute := range []bool{s.bold, s.dim, s.italic, s.underline, s.inverse, s.strike} {
+		if attribute {
+			b = appendProtoVarint(b, 3+i, 1)
+		}
+	}
+	return b
+}
+
+// UnmarshalBinary decodes a protobuf Table message, as encoded by [Model.MarshalBinary], into the model, replacing
+// its content. Styles and hyperlinks are translated back into escape sequences, so that a [Writer] renders them
+// according to its configuration and to the local terminal (e.g. dropping colors with [AutoStripColours]).
+// It implements the [encoding.BinaryUnmarshaler] interface
+func (m *Model) UnmarshalBinary(data []byte) error {
+	var header []string
+	rows := make([][]string, 0)
+	err := protoFields(data, func(n int, wire int, _ uint64, content []byte) error {
+		if n > 2 {
+			return nil
+		}
+		if wire != protoBytes {
+			return errInvalidMessage
+		}
+		row, err := unmarshalRow(content)
+		if n == 1 {
+			header = row
+		} else {
+			rows = append(rows, row)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	m.Header, m.Rows = header, rows
+	return nil
+}
+
+// unmarshalRow decodes a Row message into the row's fields
+func unmarshalRow(data []byte) ([]string, error) {
+	row := make([]string, 0)
+	err := protoFields(data, func(n int, wire int, _ uint64, content []byte) error {
+		if n != 1 {
+			return nil
+		}
+		if wire != protoBytes {
+			return errInvalidMessage
+		}
+		field, err := unmarshalCell(content)
+		row = append(row, field)
+		return err
+	})
+	return row, err
+}
+
+// unmarshalCell decodes a Cell message into a field. Cells without spans are represented by their typed value
+func unmarshalCell(data []byte) (string, error) {
+	var sb strings.Builder
+	spans := 0
+	value := ""
+	err := protoFields(data, func(n int, wire int, v uint64, content []byte) error {
+		switch {
+		case n == 1 && wire == protoBytes:
+			spans++
+			return unmarshalSpan(&sb, content)
+		case n == 2 && wire == protoVarint:
+			value = strconv.FormatInt(int64(v>>1)^-int64(v&1), 10)
+		case n == 3 && wire == protoFixed64:
+			value = strconv.FormatFloat(math.Float64frombits(v), 'g', -1, 64)
+		case n == 4 && wire == protoVarint:
+			value = strconv.FormatBool(v != 0)
+		case n <= 4:
+			return errInvalidMessage
+		}
+		return nil
+	})
+	if spans == 0 {
+		return value, err
+	}
+	return sb.String(), err
+}
+
+// unmarshalSpan decodes a Span message, appending its text to the given field along with the escape sequences
+// applying its style and its hyperlink. Escape characters are removed from the received strings, so that senders
+// cannot inject their own sequences
+func unmarshalSpan(sb *strings.Builder, data []byte) error {
+	var text, link string
+	var style sgrStyle
+	err := protoFields(data, func(n int, wire int, _ uint64, content []byte) error {
+		switch {
+		case n > 3:
+			return nil
+		case wire != protoBytes:
+			return errInvalidMessage
+		case n == 1:
+			text = strings.ReplaceAll(string(content), "\x1b", "")
+		case n == 2:
+			return unmarshalStyle(&style, content)
+		case n == 3:
+			link = string(content)
+		}
+		return nil
+	})
+	if strings.ContainsFunc(link, func(r rune) bool { return r < 0x20 || r == 0x7f }) {
+		link = ""
+	}
+
+	if link != "" {
+		sb.WriteString("\x1b]8;;" + link + "\x1b\\")
+	}
+	if sgr := style.sgr(); sgr != "" {
+		sb.WriteString(sgr + text + colorReset)
+	} else {
+		sb.WriteString(text)
+	}
+	if link != "" {
+		sb.WriteString("\x1b]8;;\x1b\\")
+	}
+	return err
+}
+
+// unmarshalStyle decodes a Style message into the given style
+func unmarshalStyle(s *sgrStyle, data []byte) error {
+	attributes := []*bool{&s.bold, &s.dim, &s.italic, &s.underline, &s.inverse, &s.strike}
+	return protoFields(data, func(n int, wire int, v uint64, content []byte) error {
+		switch {
+		case n > 2+len(attributes):
+			return nil
+		case n <= 2 && wire == protoBytes:
+			color := &s.fg
+			if n == 2 {
+				color = &s.bg
+			}
+			return unmarshalColor(color, content)
+		case n > 2 && wire == protoVarint:
+			*attributes[n-3] = v != 0
+			return nil
+		default:
+			return errInvalidMessage
+		}
+	})
+}
+
+// unmarshalColor decodes a Color message into the given color. Palette entries beyond the 256 colors are ignored
+func unmarshalColor(c *sgrColor, data []byte) error {
+	return protoFields(data, func(n int, wire int, v uint64, _ []byte) error {
+		switch {
+		case n > 2:
+			return nil
+		case wire != protoVarint:
+			return errInvalidMessage
+		case n == 1 && v <= 255:
+			*c = sgrColor{set: true, value: uint32(v)}
+		case n == 2:
+			*c = sgrColor{set: true, rgb: true, value: uint32(v & 0xffffff)}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,42 @@
+package TableWriter
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestModelBinaryRoundTrip(t *testing.T) {
+	m := &Model{
+		Header: []string{"\x1b[1mName\x1b[0m", "Link"},
+		Rows: [][]string{
+			{"\x1b[31;4mred\x1b[0m plain", "\x1b]8;;https://example.com\x1b\\site\x1b]8;;\x1b\\"},
+			{"\x1b[38;5;200mc\x1b[0m\x1b[2Jx", "3.5"},
+			{"", "true"},
+		},
+	}
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	var got Model
+	if err = got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	// Styles are normalized, while unsupported escape sequences are dropped
+	want := Model{
+		Header: []string{"\x1b[1mName\x1b[0m", "Link"},
+		Rows: [][]string{
+			{"\x1b[4;31mred\x1b[0m plain", "\x1b]8;;https://example.com\x1b\\site\x1b]8;;\x1b\\"},
+			{"\x1b[38;5;200mc\x1b[0mx", "3.5"},
+			{"", "true"},
+		},
+	}
+	if !slices.Equal(got.Header, want.Header) || !slices.EqualFunc(got.Rows, want.Rows, slices.Equal) {
+		t.Errorf("UnmarshalBinary() = %q, want %q", got, want)
+	}
+
+	if err = got.UnmarshalBinary(data[:len(data)-3]); !errors.Is(err, errInvalidMessage) {
+		t.Errorf("UnmarshalBinary() of a truncated message error = %v, want %v", err, errInvalidMessage)
+	}
+}
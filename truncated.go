@@ -0,0 +1,15 @@
+package TableWriter
+
+// CellPosition identifies a field of the rendered table. Row 0 is the header, while the data rows follow in the
+// same order as they have been written
+type CellPosition struct {
+	Row int
+	Col int
+}
+
+// TruncatedCells returns the complete content of the fields truncated by the last render, indexed by their position.
+// Interactive hosts can use it to display the full values on demand, e.g. when hovering or selecting a cell.
+// Fields belonging to columns removed by [TruncateHide] are not included. The map is replaced by each render
+func (w *Writer) TruncatedCells() map[CellPosition]string {
+	return w.truncated
+}
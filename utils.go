@@ -1,49 +1,67 @@
 package TableWriter
 
 import (
-	"syscall"
-	"unsafe"
+	"cmp"
+	"errors"
+	"fmt"
+	"unicode/utf8"
 )
 
-type dividers struct {
-	HLine  string
-	VLine  string
-	TL     string
-	TR     string
-	BL     string
-	BR     string
-	Cross  string
-	TUp    string
-	TDown  string
-	TRight string
-	TLeft  string
-	VLeft  string
-	VRight string
-}
+// ErrInvalidDividers is returned by [Writer.SetDividers] when a divider is not a single character one cell wide
+var ErrInvalidDividers = errors.New("invalid dividers")
 
-// Winsize is the structure used for ioctl calls, to obtain the terminal size.
-type winsize struct {
-	Row    uint16 // Rows number
-	Col    uint16 // Columns number (width)
-	Xpixel uint16 // Pixel's width
-	Ypixel uint16 // Pixel's Height
+// Dividers holds the characters used to draw the table's borders and dividers. See [Writer.SetDividers]
+type Dividers struct {
+	HLine      string // Horizontal lines between rows
+	VLine      string // Vertical lines between columns
+	OuterHLine string // Horizontal lines of the top and bottom borders, HLine if empty
+	OuterVLine string // Vertical lines of the left and right borders, VLine if empty
+	TL         string // Top-left corner
+	TR         string // Top-right corner
+	BL         string // Bottom-left corner
+	BR         string // Bottom-right corner
+	Cross      string // Junctions of the inner lines
+	TUp        string // Junctions of the top border with the vertical lines
+	TDown      string // Junctions of the bottom border with the vertical lines
+	TRight     string // Currently unused
+	TLeft      string // Currently unused
+	VLeft      string // Junctions of the left border with the horizontal lines
+	VRight     string // Junctions of the right border with the horizontal lines
 }
 
-// getTerminalSize retrieves the terminal's size associated to the given file descriptor
-func getTerminalSize(fd uintptr) (cols, rows int, err error) {
-	ws := &winsize{}
-
-	// TIOCGWINSZ is the constant that tells the kernel to retrieve the TTY size.
-	// Using the TIOCGWINSZ syscall is tailored to Linux/macOS.
-	ret, _, errno := syscall.Syscall(
-		syscall.SYS_IOCTL,
-		fd,
-		uintptr(syscall.TIOCGWINSZ),
-		uintptr(unsafe.Pointer(ws)),
-	)
+// SetDividers replaces the characters used to draw the table's borders and dividers, e.g. to match the tables of an
+// existing application, regardless of the [AsciiTable] flag and of the selected [Style].
+// Each divider must be a single character one cell wide, so that the lines stay aligned, otherwise an error wrapping
+// [ErrInvalidDividers] is returned and the current dividers are kept. Passing empty dividers restores the default ones
+func (w *Writer) SetDividers(d Dividers) error {
+	if d == (Dividers{}) {
+		w.dividers = nil
+		w.initDividers()
+		return nil
+	}
+	custom := d
+	custom.OuterHLine = cmp.Or(custom.OuterHLine, custom.HLine)
+	custom.OuterVLine = cmp.Or(custom.OuterVLine, custom.VLine)
+	for _, divider := range []struct{ name, value string }{
+		{"HLine", custom.HLine}, {"VLine", custom.VLine}, {"OuterHLine", custom.OuterHLine},
+		{"OuterVLine", custom.OuterVLine}, {"TL", custom.TL}, {"TR", custom.TR}, {"BL", custom.BL}, {"BR", custom.BR},
+		{"Cross", custom.Cross}, {"TUp", custom.TUp}, {"TDown", custom.TDown}, {"VLeft", custom.VLeft},
+		{"VRight", custom.VRight},
+	} {
+		if utf8.RuneCountInString(divider.value) != 1 || w.stringWidth(divider.value) != 1 {
+			return fmt.Errorf("%w: %s %q is not a single character one cell wide", ErrInvalidDividers, divider.name,
+				divider.value)
+		}
+	}
+	w.dividers = &custom
+	w.initDividers()
+	return nil
+}
 
-	if int(ret) == -1 {
-		return 0, 0, errno
+// WithDividers replaces the characters used to draw the table's borders and dividers.
+// Invalid dividers are ignored. See [Writer.SetDividers]
+func WithDividers(d Dividers) Option {
+	return func(w *Writer) {
+		_ = w.SetDividers(d)
 	}
-	return int(ws.Col), int(ws.Row), nil
 }
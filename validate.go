@@ -0,0 +1,35 @@
+package TableWriter
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnclosedEscape is wrapped by the errors of [Writer.Validate] reporting the fields that leave a style or a
+// hyperlink open
+var ErrUnclosedEscape = errors.New("unclosed escape sequence")
+
+// Validate checks the data buffered so far, without consuming it, and reports the fields whose SGR styles or
+// hyperlinks are never reset, which would color everything that follows them when printed by other tools (e.g. after
+// exporting the [Model]). Rendered tables are never affected, since the escapes left open by a field are closed at
+// the end of each of its lines and reopened on the next one.
+// The returned error joins one error wrapping [ErrUnclosedEscape] for each invalid field, whose row is 0 for the
+// header, or it is nil when all the fields are valid
+func (w *Writer) Validate() error {
+	m := w.Model()
+	errs := make([]error, 0)
+	for r, row := range append([][]string{m.Header}, m.Rows...) {
+		for c, field := range row {
+			var state escapeState
+			for seq, escape := range escapeSegments(field) {
+				if escape {
+					state.update(seq)
+				}
+			}
+			if open := state.link + state.sgr; open != "" {
+				errs = append(errs, fmt.Errorf("%w: row %d, column %d leaves %q open", ErrUnclosedEscape, r, c, open))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,413 @@
+package TableWriter
+
+import (
+	"cmp"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EmojiWidth defines how many terminal cells are used to display emoji, since terminals disagree about it
+type EmojiWidth uint
+
+const (
+	// EmojiWidthAuto guesses the width of emoji from the TERM environment variable: old terminals and the Linux
+	// console display them in a single cell, while modern terminals use two of them
+	EmojiWidthAuto EmojiWidth = iota
+	// EmojiNarrow displays emoji in a single cell
+	EmojiNarrow
+	// EmojiWide displays emoji in two cells
+	EmojiWide
+)
+
+// AmbiguousWidth defines how many terminal cells are used to display the East Asian characters of ambiguous width
+// (e.g. Greek and Cyrillic letters, arrows and circled numbers), which terminals configured for East Asian locales
+// display in two cells
+type AmbiguousWidth uint
+
+const (
+	// AmbiguousWidthAuto guesses the width of ambiguous characters from the locale environment variables
+	// (LC_ALL, LC_CTYPE and LANG): Chinese, Japanese and Korean locales display them in two cells
+	AmbiguousWidthAuto AmbiguousWidth = iota
+	// AmbiguousNarrow displays ambiguous characters in a single cell
+	AmbiguousNarrow
+	// AmbiguousWide displays ambiguous characters in two cells
+	AmbiguousWide
+)
+
+// emojiTable lists the characters displayed as emoji by default, rather than as text symbols
+var emojiTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23ec, Stride: 1},
+		{Lo: 0x23f0, Hi: 0x23f3, Stride: 3},
+		{Lo: 0x25fd, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267f, Hi: 0x2693, Stride: 20},
+		{Lo: 0x26a1, Hi: 0x26a1, Stride: 1},
+		{Lo: 0x26aa, Hi: 0x26ab, Stride: 1},
+		{Lo: 0x26bd, Hi: 0x26be, Stride: 1},
+		{Lo: 0x26c4, Hi: 0x26c5, Stride: 1},
+		{Lo: 0x26ce, Hi: 0x26d4, Stride: 6},
+		{Lo: 0x26ea, Hi: 0x26ea, Stride: 1},
+		{Lo: 0x26f2, Hi: 0x26f3, Stride: 1},
+		{Lo: 0x26f5, Hi: 0x26fa, Stride: 5},
+		{Lo: 0x26fd, Hi: 0x2705, Stride: 8},
+		{Lo: 0x270a, Hi: 0x270b, Stride: 1},
+		{Lo: 0x2728, Hi: 0x274c, Stride: 36},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27bf, Stride: 15},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b55, Stride: 5},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f004, Hi: 0x1f004, Stride: 1},
+		{Lo: 0x1f0cf, Hi: 0x1f0cf, Stride: 1},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f1e6, Hi: 0x1f1ff, Stride: 1},
+		{Lo: 0x1f201, Hi: 0x1f201, Stride: 1},
+		{Lo: 0x1f21a, Hi: 0x1f22f, Stride: 21},
+		{Lo: 0x1f232, Hi: 0x1f236, Stride: 1},
+		{Lo: 0x1f238, Hi: 0x1f23a, Stride: 1},
+		{Lo: 0x1f250, Hi: 0x1f251, Stride: 1},
+		{Lo: 0x1f300, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1},
+		{Lo: 0x1f7e0, Hi: 0x1f7eb, Stride: 1},
+		{Lo: 0x1f90c, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x1fa70, Hi: 0x1faff, Stride: 1},
+	},
+}
+
+// wideTable lists the East Asian wide and fullwidth characters, such as Chinese, Japanese and Korean ideographs, which
+// terminals always display in two cells
+var wideTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1},
+		{Lo: 0x2e80, Hi: 0x303e, Stride: 1},
+		{Lo: 0x3041, Hi: 0x33ff, Stride: 1},
+		{Lo: 0x3400, Hi: 0x4dbf, Stride: 1},
+		{Lo: 0x4e00, Hi: 0x9fff, Stride: 1},
+		{Lo: 0xa000, Hi: 0xa4cf, Stride: 1},
+		{Lo: 0xa960, Hi: 0xa97f, Stride: 1},
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1},
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1},
+		{Lo: 0xfe10, Hi: 0xfe19, Stride: 1},
+		{Lo: 0xfe30, Hi: 0xfe6f, Stride: 1},
+		{Lo: 0xff00, Hi: 0xff60, Stride: 1},
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16fe0, Hi: 0x16fe4, Stride: 1},
+		{Lo: 0x17000, Hi: 0x18cff, Stride: 1},
+		{Lo: 0x1b000, Hi: 0x1b2ff, Stride: 1},
+		{Lo: 0x20000, Hi: 0x2fffd, Stride: 1},
+		{Lo: 0x30000, Hi: 0x3fffd, Stride: 1},
+	},
+}
+
+// ambiguousTable lists the most common East Asian characters of ambiguous width.
+// Box drawing characters are excluded, since the table's borders are assumed to be narrow
+var ambiguousTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00a1, Hi: 0x00a1, Stride: 1},
+		{Lo: 0x00a4, Hi: 0x00a4, Stride: 1},
+		{Lo: 0x00a7, Hi: 0x00a8, Stride: 1},
+		{Lo: 0x00aa, Hi: 0x00aa, Stride: 1},
+		{Lo: 0x00ad, Hi: 0x00ae, Stride: 1},
+		{Lo: 0x00b0, Hi: 0x00b4, Stride: 1},
+		{Lo: 0x00b6, Hi: 0x00ba, Stride: 1},
+		{Lo: 0x00bc, Hi: 0x00bf, Stride: 1},
+		{Lo: 0x00c6, Hi: 0x00c6, Stride: 1},
+		{Lo: 0x00d0, Hi: 0x00d0, Stride: 1},
+		{Lo: 0x00d7, Hi: 0x00d8, Stride: 1},
+		{Lo: 0x00de, Hi: 0x00e1, Stride: 1},
+		{Lo: 0x00e6, Hi: 0x00e6, Stride: 1},
+		{Lo: 0x00e8, Hi: 0x00ea, Stride: 1},
+		{Lo: 0x00ec, Hi: 0x00ed, Stride: 1},
+		{Lo: 0x00f0, Hi: 0x00f0, Stride: 1},
+		{Lo: 0x00f2, Hi: 0x00f3, Stride: 1},
+		{Lo: 0x00f7, Hi: 0x00fa, Stride: 1},
+		{Lo: 0x00fc, Hi: 0x00fc, Stride: 1},
+		{Lo: 0x00fe, Hi: 0x00fe, Stride: 1},
+		{Lo: 0x0391, Hi: 0x03a1, Stride: 1},
+		{Lo: 0x03a3, Hi: 0x03a9, Stride: 1},
+		{Lo: 0x03b1, Hi: 0x03c1, Stride: 1},
+		{Lo: 0x03c3, Hi: 0x03c9, Stride: 1},
+		{Lo: 0x0401, Hi: 0x0401, Stride: 1},
+		{Lo: 0x0410, Hi: 0x044f, Stride: 1},
+		{Lo: 0x0451, Hi: 0x0451, Stride: 1},
+		{Lo: 0x2010, Hi: 0x2010, Stride: 1},
+		{Lo: 0x2013, Hi: 0x2016, Stride: 1},
+		{Lo: 0x2018, Hi: 0x2019, Stride: 1},
+		{Lo: 0x201c, Hi: 0x201d, Stride: 1},
+		{Lo: 0x2020, Hi: 0x2022, Stride: 1},
+		{Lo: 0x2024, Hi: 0x2027, Stride: 1},
+		{Lo: 0x2030, Hi: 0x2030, Stride: 1},
+		{Lo: 0x2032, Hi: 0x2033, Stride: 1},
+		{Lo: 0x2035, Hi: 0x2035, Stride: 1},
+		{Lo: 0x203b, Hi: 0x203b, Stride: 1},
+		{Lo: 0x203e, Hi: 0x203e, Stride: 1},
+		{Lo: 0x20ac, Hi: 0x20ac, Stride: 1},
+		{Lo: 0x2103, Hi: 0x2103, Stride: 1},
+		{Lo: 0x2109, Hi: 0x2109, Stride: 1},
+		{Lo: 0x2116, Hi: 0x2116, Stride: 1},
+		{Lo: 0x2121, Hi: 0x2122, Stride: 1},
+		{Lo: 0x2126, Hi: 0x2126, Stride: 1},
+		{Lo: 0x212b, Hi: 0x212b, Stride: 1},
+		{Lo: 0x2153, Hi: 0x2154, Stride: 1},
+		{Lo: 0x215b, Hi: 0x215e, Stride: 1},
+		{Lo: 0x2160, Hi: 0x216b, Stride: 1},
+		{Lo: 0x2170, Hi: 0x2179, Stride: 1},
+		{Lo: 0x2190, Hi: 0x2199, Stride: 1},
+		{Lo: 0x21d2, Hi: 0x21d2, Stride: 1},
+		{Lo: 0x21d4, Hi: 0x21d4, Stride: 1},
+		{Lo: 0x2200, Hi: 0x2200, Stride: 1},
+		{Lo: 0x2202, Hi: 0x2203, Stride: 1},
+		{Lo: 0x2207, Hi: 0x2208, Stride: 1},
+		{Lo: 0x220b, Hi: 0x220b, Stride: 1},
+		{Lo: 0x220f, Hi: 0x220f, Stride: 1},
+		{Lo: 0x2211, Hi: 0x2211, Stride: 1},
+		{Lo: 0x2215, Hi: 0x2215, Stride: 1},
+		{Lo: 0x221a, Hi: 0x221a, Stride: 1},
+		{Lo: 0x221d, Hi: 0x2220, Stride: 1},
+		{Lo: 0x2223, Hi: 0x2223, Stride: 1},
+		{Lo: 0x2225, Hi: 0x2225, Stride: 1},
+		{Lo: 0x2227, Hi: 0x222c, Stride: 1},
+		{Lo: 0x222e, Hi: 0x222e, Stride: 1},
+		{Lo: 0x2234, Hi: 0x2237, Stride: 1},
+		{Lo: 0x223c, Hi: 0x223d, Stride: 1},
+		{Lo: 0x2248, Hi: 0x2248, Stride: 1},
+		{Lo: 0x224c, Hi: 0x224c, Stride: 1},
+		{Lo: 0x2252, Hi: 0x2252, Stride: 1},
+		{Lo: 0x2260, Hi: 0x2261, Stride: 1},
+		{Lo: 0x2264, Hi: 0x2267, Stride: 1},
+		{Lo: 0x226a, Hi: 0x226b, Stride: 1},
+		{Lo: 0x226e, Hi: 0x226f, Stride: 1},
+		{Lo: 0x2282, Hi: 0x2283, Stride: 1},
+		{Lo: 0x2286, Hi: 0x2287, Stride: 1},
+		{Lo: 0x2295, Hi: 0x2295, Stride: 1},
+		{Lo: 0x2299, Hi: 0x2299, Stride: 1},
+		{Lo: 0x22a5, Hi: 0x22a5, Stride: 1},
+		{Lo: 0x22bf, Hi: 0x22bf, Stride: 1},
+		{Lo: 0x2312, Hi: 0x2312, Stride: 1},
+		{Lo: 0x2460, Hi: 0x24e9, Stride: 1},
+		{Lo: 0x24eb, Hi: 0x24ff, Stride: 1},
+		{Lo: 0x25a0, Hi: 0x25a1, Stride: 1},
+		{Lo: 0x25a3, Hi: 0x25a9, Stride: 1},
+		{Lo: 0x25b2, Hi: 0x25b3, Stride: 1},
+		{Lo: 0x25b6, Hi: 0x25b7, Stride: 1},
+		{Lo: 0x25bc, Hi: 0x25bd, Stride: 1},
+		{Lo: 0x25c0, Hi: 0x25c1, Stride: 1},
+		{Lo: 0x25c6, Hi: 0x25c8, Stride: 1},
+		{Lo: 0x25cb, Hi: 0x25cb, Stride: 1},
+		{Lo: 0x25ce, Hi: 0x25d1, Stride: 1},
+		{Lo: 0x25e2, Hi: 0x25e5, Stride: 1},
+		{Lo: 0x25ef, Hi: 0x25ef, Stride: 1},
+		{Lo: 0x2605, Hi: 0x2606, Stride: 1},
+		{Lo: 0x2609, Hi: 0x2609, Stride: 1},
+		{Lo: 0x260e, Hi: 0x260f, Stride: 1},
+		{Lo: 0x261c, Hi: 0x261c, Stride: 1},
+		{Lo: 0x261e, Hi: 0x261e, Stride: 1},
+		{Lo: 0x2640, Hi: 0x2640, Stride: 1},
+		{Lo: 0x2642, Hi: 0x2642, Stride: 1},
+		{Lo: 0x2660, Hi: 0x2661, Stride: 1},
+		{Lo: 0x2663, Hi: 0x2665, Stride: 1},
+		{Lo: 0x2667, Hi: 0x266a, Stride: 1},
+		{Lo: 0x266c, Hi: 0x266d, Stride: 1},
+		{Lo: 0x266f, Hi: 0x266f, Stride: 1},
+		{Lo: 0x273d, Hi: 0x273d, Stride: 1},
+		{Lo: 0x2776, Hi: 0x277f, Stride: 1},
+		{Lo: 0xfffd, Hi: 0xfffd, Stride: 1},
+	},
+}
+
+// SetEmojiWidth defines how many terminal cells are used to display emoji, so that tables containing them stay
+// aligned on the user's terminal. With [EmojiWidthAuto], the width set by the matching [TerminalProfile] is used, if any
+func (w *Writer) SetEmojiWidth(policy EmojiWidth) {
+	w.emojiPolicy = policy
+	w.emojiWidth = 1
+	if policy == EmojiWidthAuto {
+		// Terminal profiles take precedence over the guess
+		for _, profile := range w.detectedProfiles() {
+			policy = cmp.Or(profile.EmojiWidth, policy)
+		}
+	}
+	switch policy {
+	case EmojiWide:
+		w.emojiWidth = 2
+	case EmojiWidthAuto:
+		term := os.Getenv("TERM")
+		if term != "linux" && !strings.HasPrefix(term, "vt") {
+			w.emojiWidth = 2
+		}
+	}
+}
+
+// WithEmojiWidth defines how many terminal cells are used to display emoji. See [Writer.SetEmojiWidth]
+func WithEmojiWidth(policy EmojiWidth) Option {
+	return func(w *Writer) {
+		w.SetEmojiWidth(policy)
+	}
+}
+
+// SetAmbiguousWidth defines how many terminal cells are used to display East Asian characters of ambiguous width.
+// Terminals displaying box drawing characters in two cells too should be used with [AsciiTable]
+func (w *Writer) SetAmbiguousWidth(policy AmbiguousWidth) {
+	w.ambiguousWidth = 1
+	switch policy {
+	case AmbiguousWide:
+		w.ambiguousWidth = 2
+	case AmbiguousWidthAuto:
+		locale := cmp.Or(os.Getenv("LC_ALL"), os.Getenv("LC_CTYPE"), os.Getenv("LANG"))
+		for _, language := range []string{"zh", "ja", "ko"} {
+			if strings.HasPrefix(locale, language) {
+				w.ambiguousWidth = 2
+			}
+		}
+	}
+}
+
+// WithAmbiguousWidth defines how many terminal cells are used to display East Asian characters of ambiguous width.
+// See [Writer.SetAmbiguousWidth]
+func WithAmbiguousWidth(policy AmbiguousWidth) Option {
+	return func(w *Writer) {
+		w.SetAmbiguousWidth(policy)
+	}
+}
+
+// runeWidth returns the number of terminal cells required to display the given character
+func (w *Writer) runeWidth(r rune) int {
+	switch {
+	case unicode.Is(wideTable, r):
+		return 2
+	case unicode.Is(emojiTable, r):
+		return w.emojiWidth
+	case unicode.Is(ambiguousTable, r):
+		return w.ambiguousWidth
+	default:
+		return 1
+	}
+}
+
+// Characters combining with the surrounding ones into a single grapheme cluster
+const (
+	zeroWidthJoiner   = '\u200d'
+	textPresentation  = '\ufe0e'
+	emojiPresentation = '\ufe0f'
+)
+
+// isExtender reports whether the given character extends the grapheme cluster preceding it without taking any
+// space: combining marks, variation selectors, emoji skin tone modifiers and emoji tag characters
+func isExtender(r rune) bool {
+	return unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) ||
+		(r >= 0xFE00 && r <= 0xFE0F) || (r >= 0x1F3FB && r <= 0x1F3FF) || (r >= 0xE0020 && r <= 0xE007F)
+}
+
+// isRegionalIndicator reports whether the given character is one of the letters that, in pairs, encode flags
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// nextGrapheme returns the length in bytes of the grapheme cluster at the beginning of s, along with the number of
+// terminal cells required to display it. Emoji sequences joined by zero width joiners, flags and characters followed
+// by modifiers or variation selectors are displayed as a single character
+func (w *Writer) nextGrapheme(s string) (int, int) {
+	r, n := utf8.DecodeRuneInString(s)
+	if isExtender(r) || r == zeroWidthJoiner {
+		return n, 0
+	}
+	width := w.runeWidth(r)
+	if isRegionalIndicator(r) {
+		if next, size := utf8.DecodeRuneInString(s[n:]); isRegionalIndicator(next) {
+			return n + size, w.emojiWidth
+		}
+		return n, width
+	}
+	for n < len(s) {
+		next, size := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case next == zeroWidthJoiner:
+			// The joined character belongs to the same cluster
+			n += size
+			if n < len(s) {
+				_, size = utf8.DecodeRuneInString(s[n:])
+				n += size
+			}
+		case next == emojiPresentation:
+			width = w.emojiWidth
+			n += size
+		case next == textPresentation:
+			width = 1
+			n += size
+		case isExtender(next):
+			n += size
+		default:
+			return n, width
+		}
+	}
+	return n, width
+}
+
+// stringWidth returns the number of terminal cells required to display the given colorless string
+func (w *Writer) stringWidth(s string) int {
+	width := 0
+	for len(s) > 0 {
+		n, clusterWidth := w.nextGrapheme(s)
+		width += clusterWidth
+		s = s[n:]
+	}
+	return width
+}
+
+// graphemes splits the given colorless string into its grapheme clusters, returning them along with the visible
+// column where each of them starts. The last column is the width of the whole string
+func (w *Writer) graphemes(s string) ([]string, []int) {
+	clusters := make([]string, 0, len(s))
+	cols := []int{0}
+	for len(s) > 0 {
+		n, width := w.nextGrapheme(s)
+		clusters = append(clusters, s[:n])
+		cols = append(cols, cols[len(cols)-1]+width)
+		s = s[n:]
+	}
+	return clusters, cols
+}
+
+// fieldWidth returns the number of terminal cells required to display the given colorless field, which spans over
+// multiple lines when containing line breaks
+func (w *Writer) fieldWidth(s string) int {
+	width := 0
+	for line := range strings.Lines(s) {
+		width = max(width, w.stringWidth(strings.TrimSuffix(line, "\n")))
+	}
+	return width
+}
+
+// sliceVisible returns the portion of s displayed between the visible columns start (included) and end (excluded).
+// Wide characters and grapheme clusters are only kept when they fit entirely in the slice.
+// Escape sequences are always preserved, so that the resulting slice keeps the original styling
+func (w *Writer) sliceVisible(s string, start int, end int) string {
+	var sb strings.Builder
+	col := 0
+	for text, escape := range escapeSegments(s) {
+		if escape {
+			sb.WriteString(text)
+			continue
+		}
+		for len(text) > 0 {
+			n, width := w.nextGrapheme(text)
+			if col >= start && col+width <= end {
+				sb.WriteString(text[:n])
+			}
+			col += width
+			text = text[n:]
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,130 @@
+package TableWriter
+
+import "fmt"
+
+// wrapMarker prefixes the continuation lines of wrapped fields when [MarkWrappedLines] is set
+const (
+	wrapMarker      = "↪"
+	asciiWrapMarker = ">"
+)
+
+// isBreakAfter reports whether a line can be broken right after the given grapheme cluster.
+// Hyphens, slashes and dots are preferred in order to keep paths and URLs readable
+func isBreakAfter(char string) bool {
+	return char == "-" || char == "/" || char == "."
+}
+
+// continuationMarker returns the marker used for wrapped lines, or an empty string if it has not been requested
+func (w *Writer) continuationMarker() string {
+	switch {
+	case w.flags&MarkWrappedLines == 0:
+		return ""
+	case w.flags&AsciiTable != 0:
+		return asciiWrapMarker
+	default:
+		return w.glyph(wrapMarker)
+	}
+}
+
+// wrapField splits the given field over multiple lines of at most maxWidth visible characters.
+// Lines are broken at spaces, hyphens, slashes and dots whenever possible, while words are only split as a last resort
+func (w *Writer) wrapField(field cell, maxWidth int) []string {
+	// Visible column where each character starts, since wide characters span over multiple columns
+	clusters, cols := w.graphemes(field.plain)
+	marker := w.continuationMarker()
+	segments := make([]string, 0)
+	start := 0
+	for start < len(clusters) {
+		prefix := ""
+		limit := maxWidth
+		if len(segments) > 0 && maxWidth > w.stringWidth(marker) {
+			prefix = marker
+			limit -= w.stringWidth(marker)
+		}
+		if cols[len(clusters)]-cols[start] <= limit {
+			segments = append(segments, prefix+w.sliceVisible(field.text, cols[start], cols[len(clusters)]))
+			break
+		}
+
+		// Looking for the last break opportunity that fits the line, otherwise the word is split.
+		// Each line hosts at least one character, even if wider than the limit
+		end := start + 1
+		for end < len(clusters) && cols[end+1]-cols[start] <= limit {
+			end++
+		}
+		if end == len(clusters) {
+			segments = append(segments, prefix+w.sliceVisible(field.text, cols[start], cols[end]))
+			break
+		}
+		next := end
+		for i := end; i > start; i-- {
+			if clusters[i] == " " {
+				end, next = i, i
+				break
+			}
+			if isBreakAfter(clusters[i-1]) {
+				end, next = i, i
+				break
+			}
+		}
+		segments = append(segments, prefix+w.sliceVisible(field.text, cols[start], cols[end]))
+
+		// Spaces used to break the line are not carried over to the next one
+		for next < len(clusters) && clusters[next] == " " {
+			next++
+		}
+		start = next
+	}
+	if len(segments) == 0 {
+		segments = append(segments, field.text)
+	}
+	return segments
+}
+
+// widenWrappedColumns widens the wrapped columns to their widest line, which exceeds the column's width when it hosts
+// a single character wider than the column, so that their borders stay aligned
+func (w *Writer) widenWrappedColumns() {
+	for _, cells := range w.rows {
+		if w.isTemplateRow(cells) {
+			continue
+		}
+		for c := range cells {
+			if w.columnSpec(c).Truncate != TruncateWrap {
+				continue
+			}
+			for _, segment := range cells[c].segments {
+				w.columns[c].textWidth = max(w.columns[c].textWidth, w.stringWidth(stripEscapeCodes(segment)))
+			}
+		}
+	}
+}
+
+// SetMaxRowLines limits the number of physical lines each row can span over when its fields are wrapped.
+// Exceeding lines are replaced by a marker reporting how many of them have been omitted. Zero disables the limit
+func (w *Writer) SetMaxRowLines(n int) {
+	w.maxRowLines = max(n, 0)
+}
+
+// limitRowLines drops the segments exceeding the maximum number of lines per row, replacing them with a
+// "+N more lines" marker that fits in the given width
+func (w *Writer) limitRowLines(segments []string, maxWidth int) []string {
+	if w.maxRowLines == 0 || len(segments) <= w.maxRowLines {
+		return segments
+	}
+
+	// A single line cannot host both content and marker, so the default truncation suffix is used instead
+	if w.maxRowLines == 1 {
+		return []string{w.cutField(segments[0], maxWidth)}
+	}
+
+	omitted := len(segments) - w.maxRowLines + 1
+	marker := fmt.Sprintf("+%d more lines", omitted)
+	if w.stringWidth(marker) > maxWidth {
+		marker = w.sliceVisible(fmt.Sprintf("+%d", omitted), 0, maxWidth)
+	}
+	if w.flags&StripColours == 0 {
+		marker = colorOrange + marker + colorReset
+		w.useLegendEntry(legendTruncated)
+	}
+	return append(segments[:w.maxRowLines-1], marker)
+}
@@ -0,0 +1,46 @@
+package TableWriter
+
+import "testing"
+
+func TestWrapFieldWiderThanLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		flags uint
+		spec  ColumnSpec
+		want  string
+	}{
+		{
+			name:  "last character wider than the line after the marker",
+			input: "h\nab中\n",
+			flags: MarkWrappedLines,
+			spec:  ColumnSpec{Truncate: TruncateWrap, MaxWidth: 2},
+			want: "┌────┐\n" +
+				"│h   │\n" +
+				"├────┤\n" +
+				"│ab  │\n" +
+				"│↪中 │\n" +
+				"└────┘\n",
+		},
+		{
+			name:  "wide characters in a single-cell column",
+			input: "h\n中文字\n",
+			spec:  ColumnSpec{Truncate: TruncateWrap, MaxWidth: 1},
+			want: "┌───┐\n" +
+				"│h  │\n" +
+				"├───┤\n" +
+				"│中 │\n" +
+				"│文 │\n" +
+				"│字 │\n" +
+				"└───┘\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderTable(t, tt.input, tt.flags|StripColours, WithWidth(40), WithColumnSpec(0, tt.spec))
+			if got != tt.want {
+				t.Errorf("rendered table =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
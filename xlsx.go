@@ -0,0 +1,153 @@
+package TableWriter
+
+import (
+	"archive/zip"
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Static parts of the XLSX packages written by [Writer.ExportXLSX]
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+	// The second cell format, used by the header, is bold with a gray fill
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>` +
+		`<fill><patternFill patternType="solid"><fgColor rgb="FFD9D9D9"/></patternFill></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/></cellXfs>` +
+		`</styleSheet>`
+	// xlsxMaxColumnWidth is the widest column allowed by Excel, in characters
+	xlsxMaxColumnWidth = 255
+)
+
+// xlsxSheetName makes the given name valid for an Excel sheet, which cannot contain some characters and is limited
+// to 31 characters
+func xlsxSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return cmp.Or(name, "Sheet1")
+}
+
+// xlsxColumn returns the letters naming the column at the given index (A, B, ..., Z, AA, ...)
+func xlsxColumn(c int) string {
+	name := ""
+	for c++; c > 0; c = (c - 1) / 26 {
+		name = string(rune('A'+(c-1)%26)) + name
+	}
+	return name
+}
+
+// xmlText escapes the given text for XML documents, replacing the characters XML cannot represent
+func xmlText(s string) string {
+	var sb strings.Builder
+	// Writing to memory cannot fail
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// ExportXLSX writes the buffered data, without consuming it, to out as an Excel workbook holding a single sheet with
+// the given name. The header is styled in bold, the values of numeric columns (see [Writer.InferSchema]) are stored
+// as numbers and the columns' widths are measured as the table's ones, so that the sheet reads like the table
+func (w *Writer) ExportXLSX(out io.Writer, sheet string) error {
+	m := w.exportModel()
+	schema := w.inferSchema(m)
+
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(schema) > 0 {
+		sb.WriteString("<cols>")
+		for c, column := range schema {
+			width := min(max(column.MaxWidth, w.fieldWidth(column.Name))+2, xlsxMaxColumnWidth)
+			sb.WriteString(fmt.Sprintf(`<col min="%d" max="%d" width="%d" customWidth="1"/>`, c+1, c+1, width))
+		}
+		sb.WriteString("</cols>")
+	}
+	sb.WriteString("<sheetData>")
+	writeRow := func(r int, row []string) {
+		sb.WriteString(`<row r="` + strconv.Itoa(r+1) + `">`)
+		for c, field := range row {
+			field = stripEscapeCodes(field)
+			if field == "" {
+				continue
+			}
+			ref := xlsxColumn(c) + strconv.Itoa(r+1)
+			numeric := c < len(schema) && (schema[c].Type == TypeInteger || schema[c].Type == TypeFloat)
+			if n, err := strconv.ParseFloat(field, 64); r > 0 && numeric && err == nil && !math.IsInf(n, 0) && !math.IsNaN(n) {
+				sb.WriteString(`<c r="` + ref + `"><v>` + strconv.FormatFloat(n, 'g', -1, 64) + `</v></c>`)
+				continue
+			}
+			style := ""
+			if r == 0 {
+				style = ` s="1"`
+			}
+			sb.WriteString(`<c r="` + ref + `"` + style + ` t="inlineStr"><is><t xml:space="preserve">` +
+				xmlText(field) + `</t></is></c>`)
+		}
+		sb.WriteString("</row>")
+	}
+	if m.Header != nil {
+		writeRow(0, m.Header)
+	}
+	for r, row := range m.Rows {
+		writeRow(r+1, row)
+	}
+	sb.WriteString("</sheetData></worksheet>")
+
+	workbook := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="` + xmlText(xlsxSheetName(sheet)) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	archive := zip.NewWriter(out)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", workbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/worksheets/sheet1.xml", sb.String()},
+	}
+	for _, part := range parts {
+		f, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
@@ -0,0 +1,85 @@
+package TableWriter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// xlsxSheet exports the data buffered by the given Writer as an XLSX workbook and returns its sheet
+func xlsxSheet(t *testing.T, w *Writer) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := w.ExportXLSX(&buf, "Sheet"); err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	f, err := archive.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	sheet, _ := io.ReadAll(f)
+	return string(sheet)
+}
+
+func TestExportXLSXColumnWidths(t *testing.T) {
+	w := NewWriter(io.Discard, 0)
+	_, _ = io.WriteString(w, "id\tdescription\n1\t"+strings.Repeat("x", 1000)+"\n")
+	sheet := xlsxSheet(t, w)
+	for _, want := range []string{
+		`<col min="1" max="1" width="4" customWidth="1"/>`,
+		`<col min="2" max="2" width="255" customWidth="1"/>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet does not contain %s:\n%s", want, sheet)
+		}
+	}
+}
+
+func TestExportXLSXRoundTrip(t *testing.T) {
+	w := NewWriter(io.Discard, 0)
+	input := "Name\tScore\tNote\n\x1b[1mAlice\x1b[0m\t1.5\t <a & b> \nBob\t-2\t\nCarol\t3e2\t" + EscapeCell("x\ty") + "\n"
+	if _, err := io.WriteString(w, input); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	var sheet struct {
+		Rows []struct {
+			Cells []struct {
+				Ref    string `xml:"r,attr"`
+				Type   string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal([]byte(xlsxSheet(t, w)), &sheet); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+	got := make([][]string, len(sheet.Rows))
+	for r, row := range sheet.Rows {
+		got[r] = make([]string, 3)
+		for _, c := range row.Cells {
+			value := c.Value
+			if c.Type == "inlineStr" {
+				value = c.Inline
+			}
+			got[r][c.Ref[0]-'A'] = value
+		}
+	}
+	want := [][]string{
+		{"Name", "Score", "Note"},
+		{"Alice", "1.5", " <a & b> "},
+		{"Bob", "-2", ""},
+		{"Carol", "300", "x\ty"},
+	}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("sheet = %q, want %q", got, want)
+	}
+}